@@ -0,0 +1,75 @@
+package golive
+
+import (
+	"math"
+
+	"github.com/sqeezelemon/golive/geo"
+)
+
+// Airport is the position of an airport, which the Live API only gives through its ATC facilities and flight plans.
+type Airport struct {
+	Icao      string
+	Latitude  float64
+	Longitude float64
+	// Elevation in feet, zero if unknown
+	Elevation float64
+}
+
+// Position returns the airport's position.
+func (a Airport) Position() geo.Point {
+	return geo.Point{Latitude: a.Latitude, Longitude: a.Longitude}
+}
+
+// AirportsFromAtc returns the airports of active ATC facilities, once each, in order of appearance.
+// Their elevation is unknown.
+func AirportsFromAtc(facilities []ActiveAtcFacility) []Airport {
+	var airports []Airport
+	seen := map[string]bool{}
+	for _, facility := range facilities {
+		if seen[facility.AirportName] {
+			continue
+		}
+		seen[facility.AirportName] = true
+		airports = append(airports, Airport{Icao: facility.AirportName, Latitude: facility.Latitude, Longitude: facility.Longitude})
+	}
+	return airports
+}
+
+// AirportsFromFlightPlan returns the origin and destination of a flight plan with their elevation,
+// leaving out those it does not have.
+func AirportsFromFlightPlan(plan FlightPlan) []Airport {
+	var airports []Airport
+	if origin, ok := plan.Origin(); ok {
+		airports = append(airports, airportFromItem(origin))
+	}
+	if destination, ok := plan.Destination(); ok {
+		airports = append(airports, airportFromItem(destination))
+	}
+	return airports
+}
+
+func airportFromItem(item FlightPlanItem) Airport {
+	return Airport{
+		Icao:      item.Identifier,
+		Latitude:  item.Location.Latitude,
+		Longitude: item.Location.Longitude,
+		Elevation: item.Location.Altitude,
+	}
+}
+
+// Internal function that returns the airport nearest to p within maxDistance nautical miles,
+// and its distance
+func nearestAirport(p geo.Point, airports []Airport, maxDistance float64) (*Airport, float64) {
+	var nearest *Airport
+	best := math.Inf(1)
+	for i := range airports {
+		if d := geo.Distance(p, airports[i].Position()); d <= maxDistance && d < best {
+			nearest, best = &airports[i], d
+		}
+	}
+	if nearest == nil {
+		return nil, 0
+	}
+	airport := *nearest
+	return &airport, best
+}
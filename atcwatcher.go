@@ -0,0 +1,139 @@
+package golive
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FacilityOpened is emitted when an ATC frequency opens.
+type FacilityOpened struct {
+	Facility ActiveAtcFacility
+}
+
+// FacilityClosed is emitted when an ATC frequency closes, with its last known state.
+type FacilityClosed struct {
+	Facility ActiveAtcFacility
+	// Duration is the time from the facility's StartTime to the poll that noticed it closed.
+	Duration time.Duration
+}
+
+// ControllerChanged is emitted when another controller takes over an open frequency.
+type ControllerChanged struct {
+	Facility         ActiveAtcFacility
+	PreviousUserId   string
+	PreviousUsername string
+	// PreviousDuration is how long the previous controller was on the frequency.
+	PreviousDuration time.Duration
+}
+
+func (FacilityOpened) event()    {}
+func (FacilityClosed) event()    {}
+func (ControllerChanged) event() {}
+
+// Name returns the airport and facility type, such as "KLAX Tower".
+func (f ActiveAtcFacility) Name() string {
+	return f.AirportName + " " + f.Type.String()
+}
+
+// AtcWatcher tracks the ATC facilities of a session by frequency and emits events as they change.
+type AtcWatcher struct {
+	client    *Client
+	sessionId string
+	options   WatcherOptions
+
+	mutex       sync.Mutex
+	initialized bool
+	facilities  []ActiveAtcFacility
+	byId        map[string]ActiveAtcFacility
+}
+
+// NewAtcWatcher creates a watcher for the ATC facilities of a session.
+// A nil options uses the defaults.
+func NewAtcWatcher(client *Client, sessionId string, options *WatcherOptions) *AtcWatcher {
+	return &AtcWatcher{
+		client:    client,
+		sessionId: sessionId,
+		options:   options.withDefaults(),
+		byId:      map[string]ActiveAtcFacility{},
+	}
+}
+
+// Facilities returns the facilities seen at the last update.
+func (w *AtcWatcher) Facilities() []ActiveAtcFacility {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]ActiveAtcFacility(nil), w.facilities...)
+}
+
+// Poll fetches the active ATC facilities once and returns the events since the previous update.
+func (w *AtcWatcher) Poll(ctx context.Context) ([]Event, error) {
+	facilities, err := w.client.GetActiveAtcCtx(ctx, w.sessionId)
+	if err != nil {
+		return nil, err
+	}
+	return w.Update(facilities), nil
+}
+
+// Update compares a snapshot obtained elsewhere, such as from FacilitiesFromWorld,
+// with the previous one and returns the events between them.
+func (w *AtcWatcher) Update(facilities []ActiveAtcFacility) []Event {
+	return w.update(facilities, time.Now())
+}
+
+// Run polls until ctx is done, sending events to the returned channel,
+// which is closed when Run stops.
+func (w *AtcWatcher) Run(ctx context.Context) <-chan Event {
+	return runPoller(ctx, w.options, w.Poll)
+}
+
+// FacilitiesFromWorld collects the ATC facilities of every airport in a GetWorldStatus result.
+func FacilitiesFromWorld(statuses []AirportStatus) []ActiveAtcFacility {
+	var facilities []ActiveAtcFacility
+	for _, status := range statuses {
+		facilities = append(facilities, status.AtcFacilities...)
+	}
+	return facilities
+}
+
+func (w *AtcWatcher) update(facilities []ActiveAtcFacility, now time.Time) []Event {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	quiet := !w.initialized && w.options.SkipInitial
+	w.initialized = true
+
+	var events []Event
+	current := make(map[string]ActiveAtcFacility, len(facilities))
+	for _, facility := range facilities {
+		current[facility.FrequencyId] = facility
+		previous, ok := w.byId[facility.FrequencyId]
+		switch {
+		case !ok && !quiet:
+			events = append(events, FacilityOpened{facility})
+		case ok && previous.UserId != facility.UserId:
+			events = append(events, ControllerChanged{
+				Facility:         facility,
+				PreviousUserId:   previous.UserId,
+				PreviousUsername: previous.Username,
+				PreviousDuration: sinceStart(previous, facility.StartTime.Time()),
+			})
+		}
+	}
+	for _, facility := range w.facilities {
+		if _, ok := current[facility.FrequencyId]; !ok {
+			events = append(events, FacilityClosed{facility, sinceStart(facility, now)})
+		}
+	}
+
+	w.facilities = facilities
+	w.byId = current
+	return events
+}
+
+// Internal function that returns how long a facility was open at a time, zero if unknown
+func sinceStart(facility ActiveAtcFacility, at time.Time) time.Duration {
+	if facility.StartTime.IsZero() || at.IsZero() || at.Before(facility.StartTime.Time()) {
+		return 0
+	}
+	return at.Sub(facility.StartTime.Time())
+}
@@ -0,0 +1,89 @@
+package golive_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func TestAtcWatcher(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	watcher := golive.NewAtcWatcher(server.Client(), golivetest.ExpertSessionId, nil)
+	ctx := context.Background()
+
+	events, err := watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 facilities to open, got %+v", events)
+	}
+	if opened, ok := events[0].(golive.FacilityOpened); !ok || opened.Facility.Name() != "KLAX Tower" {
+		t.Errorf("expected KLAX Tower to open, got %+v", events[0])
+	}
+
+	var tower, ground golive.ActiveAtcFacility
+	server.Update(func(f *golivetest.Fixtures) {
+		facilities := f.Atc[golivetest.ExpertSessionId]
+		tower, ground = facilities[0], facilities[1]
+		// Ground closes, approach is handed over
+		approach := facilities[2]
+		approach.UserId = golivetest.KaiUserId
+		approach.Username = "KaiM"
+		approach.StartTime = golive.Time(approach.StartTime.Time().Add(30 * time.Minute))
+		f.Atc[golivetest.ExpertSessionId] = []golive.ActiveAtcFacility{tower, approach}
+	})
+
+	events, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	changed, ok := events[0].(golive.ControllerChanged)
+	if !ok || changed.PreviousUsername != "Tyler" || changed.Facility.Username != "KaiM" ||
+		changed.PreviousDuration != 30*time.Minute {
+		t.Errorf("unexpected controller change %+v", events[0])
+	}
+	closed, ok := events[1].(golive.FacilityClosed)
+	if !ok || closed.Facility.Name() != "KLAX Ground" {
+		t.Errorf("expected KLAX Ground to close, got %+v", events[1])
+	}
+	// Ground opened later than the tower, the duration is its own
+	if expected := time.Since(ground.StartTime.Time()); closed.Duration < expected-time.Minute || closed.Duration > expected {
+		t.Errorf("expected a duration of about %v, got %v", expected, closed.Duration)
+	}
+}
+
+func TestAtcWatcherWorld(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+	watcher := golive.NewAtcWatcher(client, golivetest.ExpertSessionId, &golive.WatcherOptions{SkipInitial: true})
+
+	world, err := client.GetWorldStatus(golivetest.ExpertSessionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events := watcher.Update(golive.FacilitiesFromWorld(world)); len(events) != 0 {
+		t.Errorf("expected initial facilities to be skipped, got %+v", events)
+	}
+	if facilities := watcher.Facilities(); len(facilities) != 3 {
+		t.Errorf("expected 3 facilities, got %d", len(facilities))
+	}
+
+	events := watcher.Update(golive.FacilitiesFromWorld(world[1:]))
+	if len(events) != 2 {
+		t.Fatalf("expected both KLAX facilities to close, got %+v", events)
+	}
+	for _, event := range events {
+		if closed, ok := event.(golive.FacilityClosed); !ok || closed.Facility.AirportName != "KLAX" {
+			t.Errorf("unexpected event %+v", event)
+		}
+	}
+}
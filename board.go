@@ -0,0 +1,169 @@
+package golive
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sqeezelemon/golive/geo"
+)
+
+// BoardFlight is a flight on an arrivals or departures board.
+type BoardFlight struct {
+	Flight Flight
+	Phase  FlightPhase
+	// Distance to the airport in nautical miles, zero if the airport is not located.
+	Distance float64
+	// ETA is how long an arriving flight takes to fly Distance at its current speed.
+	// It is zero for departures, and for arrivals that are on the ground or whose airport is not located.
+	ETA time.Duration
+}
+
+// AirportBoard holds the flights arriving at and departing from an airport, resolved from its AirportStatus.
+type AirportBoard struct {
+	Airport Airport
+	// Located reports whether the position of Airport is known, from its ATC facilities or BoardOptions.Airports.
+	Located bool
+	// Arrivals are sorted by ETA, followed by flights without one by distance.
+	Arrivals []BoardFlight
+	// Departures are sorted by distance, so flights still at the airport come first.
+	Departures []BoardFlight
+	// Missing lists the flights of the airport status that are not in the session anymore.
+	Missing []string
+}
+
+// BoardOptions provides what GetAirportBoard and GetAirportBoards would otherwise fetch.
+type BoardOptions struct {
+	// Airports locates airports without active ATC facilities, such as those from AirportsFromFlightPlan.
+	Airports []Airport
+	// Flights of the session to resolve flight ids with. If nil, they are fetched with GetFlights.
+	Flights []Flight
+}
+
+// NewAirportBoard resolves the inbound and outbound flights of an airport status into flights.
+// The airport is located from its ATC facilities, or from airports if it has none.
+// Flights are sorted by name when the airport is not located.
+func NewAirportBoard(status AirportStatus, flights []Flight, airports []Airport) AirportBoard {
+	board := AirportBoard{Airport: Airport{Icao: status.AirportIcao}}
+	candidates := append(AirportsFromAtc(status.AtcFacilities), airports...)
+	for _, airport := range candidates {
+		if strings.EqualFold(airport.Icao, status.AirportIcao) {
+			board.Airport, board.Located = airport, true
+			break
+		}
+	}
+
+	byId := make(map[string]Flight, len(flights))
+	for _, flight := range flights {
+		byId[flight.Id] = flight
+	}
+	resolve := func(flightIds []string, arriving bool) []BoardFlight {
+		result := []BoardFlight{}
+		for _, id := range flightIds {
+			flight, ok := byId[id]
+			if !ok {
+				board.Missing = append(board.Missing, id)
+				continue
+			}
+			result = append(result, board.flight(flight, arriving))
+		}
+		return result
+	}
+	board.Arrivals = resolve(status.InboundFlights, true)
+	board.Departures = resolve(status.OutboundFlights, false)
+	sortBoard(board.Arrivals)
+	sortBoard(board.Departures)
+	return board
+}
+
+// Internal method that places a flight relative to the airport of the board
+func (b AirportBoard) flight(f Flight, arriving bool) BoardFlight {
+	result := BoardFlight{Flight: f, Phase: ClassifyFlight(f, &PhaseOptions{Elevation: b.Airport.Elevation})}
+	if !b.Located {
+		return result
+	}
+	result.Distance = geo.Distance(f.Position(), b.Airport.Position())
+	if arriving && !result.Phase.slow() && f.Speed > 0 {
+		result.ETA = time.Duration(result.Distance / f.Speed * float64(time.Hour))
+	}
+	return result
+}
+
+// Internal function that sorts flights by ETA, then distance, then callsign
+func sortBoard(flights []BoardFlight) {
+	sort.SliceStable(flights, func(i, j int) bool {
+		a, b := flights[i], flights[j]
+		switch {
+		case (a.ETA > 0) != (b.ETA > 0):
+			return a.ETA > 0
+		case a.ETA != b.ETA:
+			return a.ETA < b.ETA
+		case a.Distance != b.Distance:
+			return a.Distance < b.Distance
+		}
+		return a.Flight.Callsign < b.Flight.Callsign
+	})
+}
+
+// GetAirportBoard retrieves the status of an airport and resolves its flights into a board.
+// A nil options uses the defaults.
+func (c *Client) GetAirportBoard(ctx context.Context, sessionId string, icao string, options *BoardOptions) (AirportBoard, error) {
+	var status AirportStatus
+	var statusErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		status, statusErr = c.GetAirportStatusCtx(ctx, sessionId, icao)
+	}()
+	flights, err := c.boardFlights(ctx, sessionId, options)
+	<-done
+	if statusErr != nil {
+		return AirportBoard{}, statusErr
+	}
+	if err != nil {
+		return AirportBoard{}, err
+	}
+	return NewAirportBoard(status, flights, options.airports()), nil
+}
+
+// GetAirportBoards resolves the flights of airport statuses, such as the result of GetWorldStatus, into boards
+// in the same order. If statuses is nil, they are fetched with GetWorldStatus. A nil options uses the defaults.
+func (c *Client) GetAirportBoards(ctx context.Context, sessionId string, statuses []AirportStatus, options *BoardOptions) ([]AirportBoard, error) {
+	var statusErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if statuses == nil {
+			statuses, statusErr = c.GetWorldStatusCtx(ctx, sessionId)
+		}
+	}()
+	flights, err := c.boardFlights(ctx, sessionId, options)
+	<-done
+	if statusErr != nil {
+		return nil, statusErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	boards := make([]AirportBoard, len(statuses))
+	for i, status := range statuses {
+		boards[i] = NewAirportBoard(status, flights, options.airports())
+	}
+	return boards, nil
+}
+
+// Internal method that returns the flights of the options, or fetches them
+func (c *Client) boardFlights(ctx context.Context, sessionId string, options *BoardOptions) ([]Flight, error) {
+	if options != nil && options.Flights != nil {
+		return options.Flights, nil
+	}
+	return c.GetFlightsCtx(ctx, sessionId)
+}
+
+func (o *BoardOptions) airports() []Airport {
+	if o == nil {
+		return nil
+	}
+	return o.Airports
+}
@@ -0,0 +1,98 @@
+package golive_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func boardIds(flights []golive.BoardFlight) []string {
+	ids := []string{}
+	for _, flight := range flights {
+		ids = append(ids, flight.Flight.Id)
+	}
+	return ids
+}
+
+func TestGetAirportBoard(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+
+	board, err := client.GetAirportBoard(context.Background(), golivetest.ExpertSessionId, "KLAX", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !board.Located || board.Airport.Latitude != 33.9425 || len(board.Missing) != 0 {
+		t.Errorf("unexpected board %+v", board)
+	}
+	if ids := boardIds(board.Arrivals); !reflect.DeepEqual(ids, []string{golivetest.BritishFlightId}) {
+		t.Errorf("unexpected arrivals %v", ids)
+	}
+	// About 15 nm out at 152 knots
+	if eta := board.Arrivals[0].ETA; eta < 5*time.Minute || eta > 7*time.Minute {
+		t.Errorf("unexpected ETA %v", eta)
+	}
+	// The Southwest flight is still taxiing at the airport
+	if ids := boardIds(board.Departures); !reflect.DeepEqual(ids, []string{golivetest.SouthwestFlightId, golivetest.DeltaFlightId}) {
+		t.Errorf("unexpected departures %v", ids)
+	}
+	for _, departure := range board.Departures {
+		if departure.ETA != 0 {
+			t.Errorf("%s: departures have no ETA, got %v", departure.Flight.Callsign, departure.ETA)
+		}
+	}
+	if departure := board.Departures[0]; departure.Phase != golive.PhaseTaxi || departure.Distance > 1 {
+		t.Errorf("unexpected departure %+v", departure)
+	}
+}
+
+func TestGetAirportBoards(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+
+	boards, err := client.GetAirportBoards(context.Background(), golivetest.ExpertSessionId, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boards) != 2 || boards[1].Airport.Icao != "KSFO" {
+		t.Fatalf("unexpected boards %+v", boards)
+	}
+	if arrivals := boards[1].Arrivals; len(arrivals) != 1 || arrivals[0].Distance < 250 || arrivals[0].ETA < 50*time.Minute {
+		t.Errorf("unexpected KSFO arrivals %+v", arrivals)
+	}
+
+	// Airports without ATC are located from the options, and flights gone from the session are listed as missing
+	flights := golivetest.DefaultFixtures().Flights[golivetest.ExpertSessionId]
+	statuses := []golive.AirportStatus{
+		{AirportIcao: "KSFO", InboundFlights: []string{golivetest.DeltaFlightId, "gone"}},
+		{AirportIcao: "KSAN", InboundFlights: []string{golivetest.DeltaFlightId, golivetest.BritishFlightId}},
+	}
+	before := len(server.Requests())
+	boards, err = client.GetAirportBoards(context.Background(), golivetest.ExpertSessionId, statuses, &golive.BoardOptions{
+		Airports: []golive.Airport{{Icao: "ksfo", Latitude: 37.6189, Longitude: -122.3750}},
+		Flights:  flights,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests := server.Requests()[before:]; len(requests) != 0 {
+		t.Errorf("expected no requests, got %v", requests)
+	}
+	if sfo := boards[0]; !sfo.Located || sfo.Arrivals[0].ETA == 0 || !reflect.DeepEqual(sfo.Missing, []string{"gone"}) {
+		t.Errorf("unexpected KSFO board %+v", sfo)
+	}
+	// Unlocated airports sort by callsign
+	san := boards[1]
+	if san.Located || san.Arrivals[0].Distance != 0 || san.Arrivals[0].ETA != 0 {
+		t.Errorf("unexpected KSAN board %+v", san)
+	}
+	if ids := boardIds(san.Arrivals); !reflect.DeepEqual(ids, []string{golivetest.DeltaFlightId, golivetest.BritishFlightId}) {
+		t.Errorf("unexpected KSAN arrivals %v", ids)
+	}
+}
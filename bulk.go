@@ -0,0 +1,205 @@
+package golive
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// MaxUsersPerRequest is the number of identifiers GetUserStats accepts at once.
+const MaxUsersPerRequest = 25
+
+// Default number of concurrent requests made by GetUserStatsBulk
+const defaultBulkConcurrency = 4
+
+// UserIdentifierKind tells which of the three kinds of user identifiers a value is.
+type UserIdentifierKind int
+
+const (
+	UserIdKind UserIdentifierKind = iota
+	UsernameKind
+	HashKind
+)
+
+func (k UserIdentifierKind) String() string {
+	switch k {
+	case UserIdKind:
+		return "user id"
+	case UsernameKind:
+		return "username"
+	case HashKind:
+		return "hash"
+	}
+	return "unknown identifier"
+}
+
+// BulkOptions controls how GetUserStatsBulk spreads its requests.
+type BulkOptions struct {
+	// Concurrency is the maximum number of requests in flight, 4 if zero.
+	Concurrency int
+}
+
+// UserStatsFailure describes an identifier GetUserStatsBulk could not retrieve stats for.
+type UserStatsFailure struct {
+	Kind       UserIdentifierKind
+	Identifier string
+	Err        error
+}
+
+// UserStatsBulkResult holds the stats retrieved by GetUserStatsBulk, keyed by the identifiers passed in.
+type UserStatsBulkResult struct {
+	ByUserId   map[string]UserStats
+	ByUsername map[string]UserStats
+	ByHash     map[string]UserStats
+	Failures   []UserStatsFailure
+}
+
+type userIdentifier struct {
+	kind  UserIdentifierKind
+	value string
+}
+
+// GetUserStatsBulk retrieves stats for any number of users, splitting the identifiers
+// into batches of MaxUsersPerRequest that are fetched concurrently.
+// Identifiers the API reports an error for, or leaves out, are listed in Failures;
+// if a whole batch fails, its identifiers are listed too and the first such error is returned.
+// A nil options uses the defaults.
+func (c *Client) GetUserStatsBulk(ctx context.Context, userIds []string, usernames []string, hashes []string, options *BulkOptions) (*UserStatsBulkResult, error) {
+	concurrency := defaultBulkConcurrency
+	if options != nil && options.Concurrency > 0 {
+		concurrency = options.Concurrency
+	}
+
+	var identifiers []userIdentifier
+	seen := map[userIdentifier]bool{}
+	add := func(kind UserIdentifierKind, values []string) {
+		for _, value := range values {
+			identifier := userIdentifier{kind, value}
+			if !seen[identifier] {
+				seen[identifier] = true
+				identifiers = append(identifiers, identifier)
+			}
+		}
+	}
+	add(UserIdKind, userIds)
+	add(UsernameKind, usernames)
+	add(HashKind, hashes)
+
+	result := &UserStatsBulkResult{
+		ByUserId:   map[string]UserStats{},
+		ByUsername: map[string]UserStats{},
+		ByHash:     map[string]UserStats{},
+	}
+	var (
+		mutex    sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	semaphore := make(chan struct{}, concurrency)
+	for start := 0; start < len(identifiers); start += MaxUsersPerRequest {
+		end := start + MaxUsersPerRequest
+		if end > len(identifiers) {
+			end = len(identifiers)
+		}
+		batch := identifiers[start:end]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				mutex.Lock()
+				defer mutex.Unlock()
+				result.fail(batch, ctx.Err())
+				if firstErr == nil {
+					firstErr = ctx.Err()
+				}
+				return
+			}
+
+			stats, err := c.getUserStatsBatch(ctx, batch)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				result.fail(batch, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			result.merge(batch, stats)
+		}()
+	}
+	wg.Wait()
+	return result, firstErr
+}
+
+// Internal method that requests stats for one batch of identifiers
+func (c *Client) getUserStatsBatch(ctx context.Context, batch []userIdentifier) ([]UserStats, error) {
+	var userIds, usernames, hashes []string
+	for _, identifier := range batch {
+		switch identifier.kind {
+		case UserIdKind:
+			userIds = append(userIds, identifier.value)
+		case UsernameKind:
+			usernames = append(usernames, identifier.value)
+		case HashKind:
+			hashes = append(hashes, identifier.value)
+		}
+	}
+	return c.GetUserStatsCtx(ctx, userIds, usernames, hashes)
+}
+
+// Internal method that matches the stats returned for a batch to its identifiers
+func (r *UserStatsBulkResult) merge(batch []userIdentifier, stats []UserStats) {
+	used := make([]bool, len(stats))
+	for _, identifier := range batch {
+		index := -1
+		for i, s := range stats {
+			if !used[i] && identifier.matches(s) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			r.Failures = append(r.Failures, UserStatsFailure{identifier.kind, identifier.value, ErrUserNotFound})
+			continue
+		}
+		used[index] = true
+
+		s := stats[index]
+		if s.ErrorCode != 0 {
+			r.Failures = append(r.Failures, UserStatsFailure{identifier.kind, identifier.value, ApiError(s.ErrorCode)})
+			continue
+		}
+		switch identifier.kind {
+		case UserIdKind:
+			r.ByUserId[identifier.value] = s
+		case UsernameKind:
+			r.ByUsername[identifier.value] = s
+		case HashKind:
+			r.ByHash[identifier.value] = s
+		}
+	}
+}
+
+// Internal method that records every identifier of a batch as failed
+func (r *UserStatsBulkResult) fail(batch []userIdentifier, err error) {
+	for _, identifier := range batch {
+		r.Failures = append(r.Failures, UserStatsFailure{identifier.kind, identifier.value, err})
+	}
+}
+
+func (i userIdentifier) matches(stats UserStats) bool {
+	switch i.kind {
+	case UserIdKind:
+		return strings.EqualFold(stats.UserId, i.value)
+	case UsernameKind:
+		return strings.EqualFold(stats.DiscourseUsername, i.value)
+	case HashKind:
+		return strings.EqualFold(stats.Hash, i.value)
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package golive_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func bulkServer(users int) *golivetest.Server {
+	server := golivetest.NewServer(nil)
+	server.Update(func(f *golivetest.Fixtures) {
+		for i := 0; i < users; i++ {
+			f.Users = append(f.Users, golive.UserStats{
+				UserId:            fmt.Sprintf("00000000-0000-4000-8000-%012d", i),
+				DiscourseUsername: fmt.Sprintf("pilot%d", i),
+				Hash:              fmt.Sprintf("%08X", i),
+			})
+		}
+	})
+	return server
+}
+
+func TestUserStatsBulk(t *testing.T) {
+	server := bulkServer(60)
+	defer server.Close()
+
+	var ids, names []string
+	for i := 0; i < 40; i++ {
+		ids = append(ids, fmt.Sprintf("00000000-0000-4000-8000-%012d", i))
+	}
+	for i := 40; i < 60; i++ {
+		names = append(names, fmt.Sprintf("Pilot%d", i))
+	}
+	names = append(names, "ghost", "pilot40")
+
+	result, err := server.Client().GetUserStatsBulk(context.Background(), ids, names, []string{"0000000A"}, &golive.BulkOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ByUserId) != 40 || len(result.ByUsername) != 21 || len(result.ByHash) != 1 {
+		t.Errorf("unexpected result sizes %d, %d, %d", len(result.ByUserId), len(result.ByUsername), len(result.ByHash))
+	}
+	if stats := result.ByUsername["Pilot45"]; stats.UserId != "00000000-0000-4000-8000-000000000045" {
+		t.Errorf("unexpected stats for Pilot45: %+v", stats)
+	}
+	if stats := result.ByHash["0000000A"]; stats.DiscourseUsername != "pilot10" {
+		t.Errorf("unexpected stats for hash 0000000A: %+v", stats)
+	}
+	if len(result.Failures) != 1 || result.Failures[0].Identifier != "ghost" ||
+		result.Failures[0].Kind != golive.UsernameKind || !errors.Is(result.Failures[0].Err, golive.ErrUserNotFound) {
+		t.Errorf("unexpected failures %+v", result.Failures)
+	}
+	// 62 unique identifiers need 3 requests
+	if requests := len(server.Requests()); requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestUserStatsBulkBatchFailure(t *testing.T) {
+	server := bulkServer(30)
+	defer server.Close()
+	server.Inject(golivetest.Fault{Path: "users", Status: http.StatusInternalServerError, Times: 1})
+
+	var ids []string
+	for i := 0; i < 30; i++ {
+		ids = append(ids, fmt.Sprintf("00000000-0000-4000-8000-%012d", i))
+	}
+	result, err := server.Client().GetUserStatsBulk(context.Background(), ids, nil, nil, &golive.BulkOptions{Concurrency: 1})
+	var httpErr *golive.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("expected HTTPError, got %v", err)
+	}
+	if len(result.ByUserId)+len(result.Failures) != 30 || len(result.Failures) == 0 {
+		t.Errorf("expected every identifier accounted for, got %d results and %d failures",
+			len(result.ByUserId), len(result.Failures))
+	}
+}
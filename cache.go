@@ -0,0 +1,308 @@
+package golive
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API responses for a client configured with WithCache.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, unless it is missing or expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key until ttl has elapsed.
+	Set(key string, value []byte, ttl time.Duration)
+	// Invalidate removes the entries whose key starts with prefix, every entry if prefix is empty.
+	Invalidate(prefix string)
+}
+
+// DefaultCacheTTLs caches the endpoints whose data rarely changes.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"sessions":            time.Minute,
+	"tracks":              10 * time.Minute,
+	"aircraft":            24 * time.Hour,
+	"aircraft/liveries":   24 * time.Hour,
+	"aircraft/*/liveries": 24 * time.Hour,
+}
+
+// WithCache caches successful responses in cache for as long as ttls gives for their path,
+// keyed by API key, method, path and body, so clients with different keys can share a cache
+// without serving each other's responses. Paths are matched without their query against path.Match patterns,
+// such as "users/*" for GetUserGrade, an exact path being preferred over a pattern and a longer pattern
+// over a shorter one. Paths without a TTL are not cached. A nil ttls uses DefaultCacheTTLs.
+//
+// Concurrent identical GET requests, and requests to cached paths, are sent only once
+// and share the response.
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	return func(c *Client) {
+		if ttls == nil {
+			ttls = DefaultCacheTTLs
+		}
+		c.cache = cache
+		c.cacheTTLs = make(map[string]time.Duration, len(ttls))
+		for pattern, ttl := range ttls {
+			c.cacheTTLs[pattern] = ttl
+		}
+	}
+}
+
+// InvalidateCache removes the responses cached by the client for a path, such as "aircraft",
+// whatever their method, body and query, so "users/{id}/flights" removes every page of a logbook.
+func (c *Client) InvalidateCache(path string) {
+	if c.cache != nil {
+		path, _ = splitQuery(path)
+		c.cache.Invalidate(c.cachePartition() + path + " ")
+	}
+}
+
+// ClearCache removes every response cached by the client, leaving those of clients with other API keys.
+func (c *Client) ClearCache() {
+	if c.cache != nil {
+		c.cache.Invalidate(c.cachePartition())
+	}
+}
+
+// Internal method that returns how long responses for a path are cached, zero if they are not
+func (c *Client) cacheTTL(requestPath string) time.Duration {
+	requestPath, _ = splitQuery(requestPath)
+	if ttl, ok := c.cacheTTLs[requestPath]; ok {
+		return ttl
+	}
+	var best string
+	var ttl time.Duration
+	for pattern, patternTtl := range c.cacheTTLs {
+		if matched, _ := path.Match(pattern, requestPath); !matched {
+			continue
+		}
+		if len(pattern) > len(best) || len(pattern) == len(best) && pattern < best {
+			best, ttl = pattern, patternTtl
+		}
+	}
+	return ttl
+}
+
+// Internal method that returns the start of the cache keys of the client,
+// which partitions a shared cache by API key and base URL
+func (c *Client) cachePartition() string {
+	sum := sha256.Sum256([]byte(c.Key))
+	return hex.EncodeToString(sum[:]) + " " + c.baseUrl
+}
+
+// Internal method that returns the cache key of a request.
+// The path comes before its query, so that every page of a path shares a prefix.
+func (c *Client) cacheKey(method string, path string, body []byte) string {
+	path, query := splitQuery(path)
+	key := c.cachePartition() + path + " " + method
+	if query != "" {
+		key += " " + query
+	}
+	if body != nil {
+		sum := sha256.Sum256(body)
+		key += " " + hex.EncodeToString(sum[:])
+	}
+	return key
+}
+
+// Internal function that splits a path from its query, which starts with ? if there is one
+func splitQuery(path string) (string, string) {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		return path[:i], path[i:]
+	}
+	return path, ""
+}
+
+// Internal method that serves a request from the cache, or performs it once for all concurrent callers
+func (c *Client) doCached(ctx context.Context, method string, path string, body []byte, result envelope) error {
+	ttl := c.cacheTTL(path)
+	key := c.cacheKey(method, path, body)
+	if ttl > 0 {
+		if raw, ok := c.cache.Get(key); ok {
+			return decodeRaw(method, path, raw, result, nil)
+		}
+	}
+
+	raw, err := c.inflight.do(ctx, key, func() ([]byte, error) {
+		var response rawResponse
+		err := c.send(ctx, method, path, body, &response)
+		if err == nil && ttl > 0 {
+			c.cache.Set(key, response.raw, ttl)
+		}
+		return response.raw, err
+	})
+	return decodeRaw(method, path, raw, result, err)
+}
+
+// Internal function that decodes a raw response into result, returning err unless decoding fails
+func decodeRaw(method string, path string, raw []byte, result envelope, err error) error {
+	result.reset()
+	if raw == nil {
+		return err
+	}
+	if decodeErr := json.Unmarshal(raw, result); decodeErr != nil {
+		return &RequestError{method, path, decodeErr}
+	}
+	return err
+}
+
+// rawResponse keeps a response envelope as it was received, along with its error code.
+type rawResponse struct {
+	raw  []byte
+	code int
+}
+
+func (r *rawResponse) UnmarshalJSON(b []byte) error {
+	var envelope struct {
+		ErrorCode int `json:"errorCode"`
+	}
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return err
+	}
+	r.raw = append([]byte(nil), b...)
+	r.code = envelope.ErrorCode
+	return nil
+}
+
+func (r *rawResponse) errorCode() int {
+	return r.code
+}
+
+func (r *rawResponse) reset() {
+	*r = rawResponse{}
+}
+
+////// SINGLEFLIGHT
+
+// flightGroup runs a function once for concurrent callers with the same key.
+type flightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	raw  []byte
+	err  error
+}
+
+// Internal method that runs fn, or waits for the call already running for key and shares its result.
+// If that call ends with its own context, fn is run again for callers whose context is still alive.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) ([]byte, error) {
+	for {
+		g.mutex.Lock()
+		call, shared := g.calls[key]
+		if !shared {
+			if g.calls == nil {
+				g.calls = map[string]*flightCall{}
+			}
+			call = &flightCall{done: make(chan struct{})}
+			g.calls[key] = call
+		}
+		g.mutex.Unlock()
+
+		if !shared {
+			call.raw, call.err = fn()
+			g.mutex.Lock()
+			delete(g.calls, key)
+			g.mutex.Unlock()
+			close(call.done)
+			return call.raw, call.err
+		}
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if ctx.Err() == nil && (errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded)) {
+			continue
+		}
+		return call.raw, call.err
+	}
+}
+
+////// MEMORY CACHE
+
+// MemoryCache is an in-memory Cache evicting the least recently used entries beyond its capacity.
+type MemoryCache struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	// Most recently used first
+	order *list.List
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates a cache holding up to maxEntries responses, without limit if zero.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		m.remove(element)
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry := &memoryEntry{key, value, time.Now().Add(ttl)}
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *MemoryCache) Invalidate(prefix string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for key, element := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			m.remove(element)
+		}
+	}
+}
+
+// Len returns the number of entries, expired ones included until they are looked up or evicted.
+func (m *MemoryCache) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.order.Len()
+}
+
+func (m *MemoryCache) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
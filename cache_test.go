@@ -0,0 +1,262 @@
+package golive_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+// Internal function that counts the requests served for a route
+func countRequests(server *golivetest.Server, route string) int {
+	count := 0
+	for _, request := range server.Requests() {
+		if request == route {
+			count++
+		}
+	}
+	return count
+}
+
+func TestClientCache(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client(golive.WithCache(golive.NewMemoryCache(100), nil))
+
+	for i := 0; i < 3; i++ {
+		aircraft, err := client.GetAircraft()
+		if err != nil || len(aircraft) == 0 {
+			t.Fatalf("unexpected aircraft %v, %v", aircraft, err)
+		}
+	}
+	if count := countRequests(server, "GET aircraft"); count != 1 {
+		t.Errorf("expected aircraft to be fetched once, got %d", count)
+	}
+
+	// Cached responses are decoded for every caller, changing one does not affect the others
+	first, _ := client.GetAircraft()
+	first[0].Name = "Changed"
+	if second, _ := client.GetAircraft(); second[0].Name == "Changed" {
+		t.Error("cached response was shared")
+	}
+
+	client.InvalidateCache("aircraft")
+	if _, err := client.GetAircraft(); err != nil {
+		t.Fatal(err)
+	}
+	if count := countRequests(server, "GET aircraft"); count != 2 {
+		t.Errorf("expected aircraft to be fetched again after invalidation, got %d", count)
+	}
+
+	// Live data is not cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetFlights(golivetest.ExpertSessionId); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if count := countRequests(server, "GET sessions/"+golivetest.ExpertSessionId+"/flights"); count != 2 {
+		t.Errorf("expected flights to be fetched every time, got %d", count)
+	}
+
+	// Errors are not cached
+	server.Inject(golivetest.Fault{Path: "tracks", Status: 500, Times: 1})
+	noRetries := server.Client(golive.WithCache(golive.NewMemoryCache(0), nil), golive.WithRetryPolicy(golive.RetryPolicy{MaxAttempts: 1}))
+	if _, err := noRetries.GetTracks(); err == nil {
+		t.Fatal("expected the injected error")
+	}
+	if _, err := noRetries.GetTracks(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientCacheBodies(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client(golive.WithCache(golive.NewMemoryCache(0), map[string]time.Duration{"users": time.Minute}))
+
+	for i := 0; i < 2; i++ {
+		kai, err := client.GetUserStats([]string{golivetest.KaiUserId}, nil, nil)
+		if err != nil || len(kai) != 1 || kai[0].UserId != golivetest.KaiUserId {
+			t.Fatalf("unexpected stats %+v, %v", kai, err)
+		}
+		laura, err := client.GetUserStats([]string{golivetest.LauraUserId}, nil, nil)
+		if err != nil || len(laura) != 1 || laura[0].UserId != golivetest.LauraUserId {
+			t.Fatalf("unexpected stats %+v, %v", laura, err)
+		}
+	}
+	if count := countRequests(server, "POST users"); count != 2 {
+		t.Errorf("expected one request per body, got %d", count)
+	}
+}
+
+func TestClientCacheInvalidatePages(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client(golive.WithCache(golive.NewMemoryCache(0), map[string]time.Duration{"users/*/flights": time.Minute}))
+	route := "GET users/" + golivetest.KaiUserId + "/flights"
+
+	fetch := func() {
+		for page := 1; page <= 2; page++ {
+			if _, err := client.GetUserFlights(golivetest.KaiUserId, page); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	fetch()
+	fetch()
+	if count := countRequests(server, route); count != 2 {
+		t.Errorf("expected each page to be fetched once, got %d", count)
+	}
+
+	client.InvalidateCache("users/" + golivetest.KaiUserId + "/flights")
+	fetch()
+	if count := countRequests(server, route); count != 4 {
+		t.Errorf("expected every page to be fetched again after invalidation, got %d", count)
+	}
+}
+
+func TestClientCachePartition(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	server.APIKey = "right"
+	cache := golive.NewMemoryCache(0)
+	client := server.Client(golive.WithCache(cache, nil))
+	if _, err := client.GetAircraft(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A client with another key sharing the cache is not served the cached response
+	other := golive.NewClient("wrong", nil, golive.WithBaseURL(server.URL+golivetest.BasePath), golive.WithCache(cache, nil))
+	if _, err := other.GetAircraft(); err == nil {
+		t.Error("expected the request with the wrong key to fail")
+	}
+
+	other.ClearCache()
+	if _, err := client.GetAircraft(); err != nil {
+		t.Fatal(err)
+	}
+	if count := countRequests(server, "GET aircraft"); count != 2 {
+		t.Errorf("expected the cached response to be kept after another client cleared its cache, got %d requests", count)
+	}
+	client.ClearCache()
+	if cache.Len() != 0 {
+		t.Errorf("expected an empty cache, got %d entries", cache.Len())
+	}
+}
+
+func TestClientSingleflight(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	server.SetLatency(100 * time.Millisecond)
+	client := server.Client(golive.WithCache(golive.NewMemoryCache(0), nil))
+
+	var wait sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			flights, err := client.GetFlights(golivetest.ExpertSessionId)
+			if err == nil && len(flights) == 0 {
+				err = errors.New("no flights")
+			}
+			errs <- err
+		}()
+	}
+	wait.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if count := countRequests(server, "GET sessions/"+golivetest.ExpertSessionId+"/flights"); count != 1 {
+		t.Errorf("expected concurrent requests to be sent once, got %d", count)
+	}
+
+	// A caller whose context ends does not fail the others
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		_, err := client.GetSessionsCtx(ctx)
+		done <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	if _, err := client.GetSessions(); err != nil {
+		t.Errorf("expected the second caller to succeed, got %v", err)
+	}
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the first caller to time out, got %v", err)
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := golive.NewMemoryCache(2)
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+	cache.Get("a")
+	cache.Set("c", []byte("3"), time.Minute)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	if value, ok := cache.Get("a"); !ok || string(value) != "1" {
+		t.Errorf("unexpected value %q", value)
+	}
+
+	cache.Set("d", []byte("4"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.Get("d"); ok {
+		t.Error("expected the entry to expire")
+	}
+
+	cache.Invalidate("")
+	if cache.Len() != 0 {
+		t.Errorf("expected an empty cache, got %d entries", cache.Len())
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := golive.NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Set("https://example.com/aircraft GET", []byte(`{"errorCode":0}`), time.Minute)
+	cache.Set("https://example.com/tracks GET", []byte(`{}`), time.Minute)
+	cache.Set("https://example.com/sessions GET", []byte(`{}`), time.Nanosecond)
+
+	// Another process sees the entries
+	other, _ := golive.NewDiskCache(dir)
+	if value, ok := other.Get("https://example.com/aircraft GET"); !ok || string(value) != `{"errorCode":0}` {
+		t.Errorf("unexpected value %q", value)
+	}
+	time.Sleep(time.Millisecond)
+	if _, ok := other.Get("https://example.com/sessions GET"); ok {
+		t.Error("expected the entry to expire")
+	}
+	other.Invalidate("https://example.com/aircraft ")
+	if _, ok := cache.Get("https://example.com/aircraft GET"); ok {
+		t.Error("expected the entry to be invalidated")
+	}
+	if _, ok := cache.Get("https://example.com/tracks GET"); !ok {
+		t.Error("expected other entries to be kept")
+	}
+
+	// Through a client
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	for i := 0; i < 2; i++ {
+		client := server.Client(golive.WithCache(cache, nil))
+		if _, err := client.GetLiveries(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if count := countRequests(server, "GET aircraft/liveries"); count != 1 {
+		t.Errorf("expected liveries to be fetched once, got %d", count)
+	}
+}
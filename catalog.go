@@ -0,0 +1,309 @@
+package golive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Catalog resolves the aircraft and livery ids of flights and logbooks to their names.
+// It is filled by Refresh or Load and is safe for concurrent use.
+type Catalog struct {
+	client *Client
+
+	mutex      sync.RWMutex
+	aircraft   []Aircraft
+	liveries   []Livery
+	aircraftBy map[string]Aircraft
+	liveryBy   map[string]Livery
+	byAircraft map[string][]Livery
+	updated    time.Time
+}
+
+// CatalogSnapshot is the content of a Catalog, as saved to JSON by Catalog.Save.
+type CatalogSnapshot struct {
+	Aircraft []Aircraft `json:"aircraft"`
+	Liveries []Livery   `json:"liveries"`
+	Updated  Time       `json:"updated"`
+}
+
+// CatalogEntry is a livery along with its aircraft.
+type CatalogEntry struct {
+	Aircraft Aircraft
+	Livery   Livery
+}
+
+// Name returns the aircraft and livery names, such as "Airbus A320 – Delta",
+// or only the one that is known.
+func (e CatalogEntry) Name() string {
+	switch {
+	case e.Aircraft.Name == "":
+		return e.Livery.LiveryName
+	case e.Livery.LiveryName == "":
+		return e.Aircraft.Name
+	}
+	return e.Aircraft.Name + " – " + e.Livery.LiveryName
+}
+
+// NewCatalog creates an empty catalog refreshed with client, which may be nil for a catalog only loaded from files.
+func NewCatalog(client *Client) *Catalog {
+	c := &Catalog{client: client}
+	c.set(nil, nil, time.Time{})
+	return c
+}
+
+// Refresh fetches the aircraft and liveries from the Live API.
+// The catalog is left as it was if either request fails.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	if c.client == nil {
+		return errors.New("golive: catalog has no client to refresh with")
+	}
+	var aircraft []Aircraft
+	var aircraftErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		aircraft, aircraftErr = c.client.GetAircraftCtx(ctx)
+	}()
+	liveries, err := c.client.GetLiveriesCtx(ctx)
+	<-done
+	if err != nil {
+		return err
+	}
+	if aircraftErr != nil {
+		return aircraftErr
+	}
+	c.set(aircraft, liveries, time.Now())
+	return nil
+}
+
+// Updated returns when the catalog was last refreshed, zero if it never was.
+func (c *Catalog) Updated() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.updated
+}
+
+// Internal method that replaces the content of the catalog
+func (c *Catalog) set(aircraft []Aircraft, liveries []Livery, updated time.Time) {
+	aircraftBy := make(map[string]Aircraft, len(aircraft))
+	for _, a := range aircraft {
+		aircraftBy[a.Id] = a
+	}
+	liveryBy := make(map[string]Livery, len(liveries))
+	byAircraft := map[string][]Livery{}
+	for _, livery := range liveries {
+		liveryBy[livery.Id] = livery
+		byAircraft[livery.AircraftID] = append(byAircraft[livery.AircraftID], livery)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.aircraft = aircraft
+	c.liveries = liveries
+	c.aircraftBy = aircraftBy
+	c.liveryBy = liveryBy
+	c.byAircraft = byAircraft
+	c.updated = updated
+}
+
+// Aircraft returns an aircraft by id.
+func (c *Catalog) Aircraft(aircraftId string) (Aircraft, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	aircraft, ok := c.aircraftBy[aircraftId]
+	return aircraft, ok
+}
+
+// Livery returns a livery by id.
+func (c *Catalog) Livery(liveryId string) (Livery, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	livery, ok := c.liveryBy[liveryId]
+	return livery, ok
+}
+
+// AllAircraft returns every aircraft in the order of the Live API.
+func (c *Catalog) AllAircraft() []Aircraft {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return append([]Aircraft(nil), c.aircraft...)
+}
+
+// Liveries returns the liveries of an aircraft in the order of the Live API.
+func (c *Catalog) Liveries(aircraftId string) []Livery {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return append([]Livery(nil), c.byAircraft[aircraftId]...)
+}
+
+// Resolve returns the aircraft and livery of a flight or logbook entry.
+// It returns false unless both are known, the entry then holds whichever was found,
+// with the aircraft named after the livery if only the livery is known.
+func (c *Catalog) Resolve(aircraftId string, liveryId string) (CatalogEntry, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	aircraft, aircraftOk := c.aircraftBy[aircraftId]
+	livery, liveryOk := c.liveryBy[liveryId]
+	if !aircraftOk && liveryOk {
+		aircraft = Aircraft{Id: livery.AircraftID, Name: livery.AircraftName}
+	}
+	return CatalogEntry{aircraft, livery}, aircraftOk && liveryOk
+}
+
+// ResolveFlight is like Resolve for the aircraft and livery of a flight.
+func (c *Catalog) ResolveFlight(f Flight) (CatalogEntry, bool) {
+	return c.Resolve(f.AircraftId, f.LiveryId)
+}
+
+// Search returns up to limit liveries whose aircraft and livery names match query, best matches first,
+// or all of them if limit is zero. Every word of the query must match the start of a word of the names,
+// appear in one, or be one typo away from one, so "a320 delta" and "boing 777" both find something.
+func (c *Catalog) Search(query string, limit int) []CatalogEntry {
+	terms := searchWords(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	c.mutex.RLock()
+	type match struct {
+		entry CatalogEntry
+		score int
+	}
+	var matches []match
+	for _, livery := range c.liveries {
+		aircraft, ok := c.aircraftBy[livery.AircraftID]
+		if !ok {
+			aircraft = Aircraft{Id: livery.AircraftID, Name: livery.AircraftName}
+		}
+		words := searchWords(aircraft.Name + " " + livery.LiveryName)
+		score := 0
+		for _, term := range terms {
+			best := 0
+			for _, word := range words {
+				if s := wordScore(term, word); s > best {
+					best = s
+				}
+			}
+			if best == 0 {
+				score = 0
+				break
+			}
+			score += best
+		}
+		if score > 0 {
+			matches = append(matches, match{CatalogEntry{aircraft, livery}, score})
+		}
+	}
+	c.mutex.RUnlock()
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].entry.Name() < matches[j].entry.Name()
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	entries := make([]CatalogEntry, len(matches))
+	for i, m := range matches {
+		entries[i] = m.entry
+	}
+	return entries
+}
+
+// Internal function that splits text into lowercase words of letters and digits
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Internal function that scores how well a search term matches a word, zero if it does not
+func wordScore(term string, word string) int {
+	switch {
+	case term == word:
+		return 4
+	case strings.HasPrefix(word, term):
+		return 3
+	case strings.Contains(word, term):
+		return 2
+	case len(term) >= 4 && editDistance(term, word) <= 1:
+		return 1
+	}
+	return 0
+}
+
+// Internal function that returns the Levenshtein distance between two strings
+func editDistance(a string, b string) int {
+	x, y := []rune(a), []rune(b)
+	previous := make([]int, len(y)+1)
+	current := make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(y)]
+}
+
+// Snapshot returns the content of the catalog.
+func (c *Catalog) Snapshot() CatalogSnapshot {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return CatalogSnapshot{
+		Aircraft: append([]Aircraft(nil), c.aircraft...),
+		Liveries: append([]Livery(nil), c.liveries...),
+		Updated:  Time(c.updated),
+	}
+}
+
+// Restore replaces the content of the catalog with a snapshot.
+func (c *Catalog) Restore(snapshot CatalogSnapshot) {
+	c.set(append([]Aircraft(nil), snapshot.Aircraft...), append([]Livery(nil), snapshot.Liveries...), snapshot.Updated.Time())
+}
+
+// Save writes a snapshot of the catalog to a JSON file, for use offline with Load.
+func (c *Catalog) Save(path string) error {
+	b, err := json.MarshalIndent(c.Snapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Load replaces the content of the catalog with a JSON file written by Save.
+func (c *Catalog) Load(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var snapshot CatalogSnapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return err
+	}
+	c.Restore(snapshot)
+	return nil
+}
@@ -0,0 +1,100 @@
+package golive_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func TestCatalog(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	catalog := golive.NewCatalog(server.Client())
+	if _, ok := catalog.Resolve(golivetest.A320Id, golivetest.DeltaId); ok {
+		t.Fatal("an empty catalog resolved a livery")
+	}
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if catalog.Updated().IsZero() {
+		t.Error("expected the update time to be set")
+	}
+
+	entry, ok := catalog.Resolve(golivetest.A320Id, golivetest.DeltaId)
+	if !ok || entry.Name() != "Airbus A320 – Delta" {
+		t.Errorf("unexpected entry %q, %v", entry.Name(), ok)
+	}
+	flight := golivetest.DefaultFixtures().Flights[golivetest.ExpertSessionId][1]
+	if entry, ok := catalog.ResolveFlight(flight); !ok || entry.Name() != "Boeing 777-300ER – British Airways" {
+		t.Errorf("unexpected entry %q, %v", entry.Name(), ok)
+	}
+	if entry, ok := catalog.Resolve(golivetest.A320Id, "unknown"); ok || entry.Name() != "Airbus A320" {
+		t.Errorf("unexpected entry %q, %v", entry.Name(), ok)
+	}
+
+	liveries := catalog.Liveries(golivetest.B77WId)
+	if len(liveries) != 2 || liveries[0].LiveryName != "British Airways" || liveries[1].LiveryName != "Emirates" {
+		t.Errorf("unexpected liveries %+v", liveries)
+	}
+	if aircraft := catalog.AllAircraft(); len(aircraft) != 4 {
+		t.Errorf("expected 4 aircraft, got %d", len(aircraft))
+	}
+
+	searches := []struct {
+		query    string
+		expected []string
+	}{
+		{"a320 delta", []string{"Airbus A320 – Delta"}},
+		{"boing 777", []string{"Boeing 777-300ER – British Airways", "Boeing 777-300ER – Emirates"}},
+		{"generic", []string{"Airbus A320 – Generic", "Cessna 172 – Generic"}},
+		{"SOUTH", []string{"Boeing 737-800 – Southwest Airlines"}},
+		{"concorde", []string{}},
+	}
+	for _, search := range searches {
+		names := []string{}
+		for _, entry := range catalog.Search(search.query, 0) {
+			names = append(names, entry.Name())
+		}
+		if !reflect.DeepEqual(names, search.expected) {
+			t.Errorf("%q: expected %v, got %v", search.query, search.expected, names)
+		}
+	}
+	// Exact words rank first
+	if results := catalog.Search("boeing 737", 1); len(results) != 1 || results[0].Livery.Id != golivetest.SouthId {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	// A saved catalog works offline
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := catalog.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	offline := golive.NewCatalog(nil)
+	if err := offline.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	saved, loaded := catalog.Snapshot(), offline.Snapshot()
+	if !reflect.DeepEqual(loaded.Aircraft, saved.Aircraft) || !reflect.DeepEqual(loaded.Liveries, saved.Liveries) ||
+		!loaded.Updated.Time().Equal(saved.Updated.Time()) {
+		t.Error("loaded catalog differs from the saved one")
+	}
+	if entry, ok := offline.Resolve(golivetest.C172Id, golivetest.CessnaId); !ok || entry.Name() != "Cessna 172 – Generic" {
+		t.Errorf("unexpected entry %q, %v", entry.Name(), ok)
+	}
+	if err := offline.Refresh(context.Background()); err == nil {
+		t.Error("expected a catalog without a client to fail to refresh")
+	}
+
+	// A failed refresh keeps the catalog
+	server.Inject(golivetest.Fault{Path: "aircraft", Status: 404})
+	if err := catalog.Refresh(context.Background()); err == nil {
+		t.Fatal("expected the injected error")
+	}
+	if _, ok := catalog.Resolve(golivetest.A320Id, golivetest.DeltaId); !ok {
+		t.Error("a failed refresh emptied the catalog")
+	}
+}
@@ -2,169 +2,250 @@ package golive
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
-const baseUrl = "https://api.infiniteflight.com/public/v2/"
+// Upper bound on how much of a response body is drained after decoding
+const maxDrainSize = 4 << 10
+
+// DefaultBaseURL is the Live API root used unless overridden with WithBaseURL.
+const DefaultBaseURL = "https://api.infiniteflight.com/public/v2/"
 
 type Client struct {
-	client *http.Client
-	Key    string
+	client    *http.Client
+	Key       string
+	baseUrl   string
+	userAgent string
+	header    http.Header
+	retry     RetryPolicy
+	limiter   *RateLimiter
+	cache     Cache
+	cacheTTLs map[string]time.Duration
+	inflight  flightGroup
 }
 
 // NewClient creates a new golive.Client with the given API key and http.Client
+// (http.DefaultClient if nil), configured by any number of options.
 // See [User Guide] for help obtaining the API key
 //
 // [User Guide]: https://infiniteflight.com/guide/developer-reference/live-api/overview
-func NewClient(apikey string, client *http.Client) *Client {
-	return &Client{
-		client: client,
-		Key:    apikey,
+func NewClient(apikey string, client *http.Client, opts ...Option) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	c := &Client{
+		client:  client,
+		Key:     apikey,
+		baseUrl: DefaultBaseURL,
+		header:  make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
-// Internal method for GET requests
-func (c *Client) get(path string) (*json.Decoder, error) {
-	var decoder *json.Decoder
-	url := baseUrl + path
-	request, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return decoder, err
+// Internal method that applies the per-client headers to a request
+func (c *Client) setHeaders(request *http.Request) {
+	for key, values := range c.header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
 	}
-
-	request.Header.Add("Authorization", "Bearer "+c.Key)
-	response, err := c.client.Do(request)
-	if err != nil {
-		return decoder, err
+	if c.userAgent != "" {
+		request.Header.Set("User-Agent", c.userAgent)
 	}
+	request.Header.Set("Authorization", "Bearer "+c.Key)
+}
 
-	decoder = json.NewDecoder(response.Body)
-	return decoder, err
+// Internal method for GET requests
+func (c *Client) get(ctx context.Context, path string, result envelope) error {
+	return c.do(ctx, http.MethodGet, path, nil, result)
 }
 
 // Internal method for POST requests
-func (c *Client) post(path string, body io.Reader) (*json.Decoder, error) {
-	var decoder *json.Decoder
-	url := baseUrl + path
-	request, err := http.NewRequest("POST", url, body)
+func (c *Client) post(ctx context.Context, path string, body []byte, result envelope) error {
+	return c.do(ctx, http.MethodPost, path, body, result)
+}
+
+// Internal method that performs a request through the client's cache, if it has one.
+func (c *Client) do(ctx context.Context, method string, path string, body []byte, result envelope) error {
+	if c.cache != nil && (method == http.MethodGet || c.cacheTTL(path) > 0) {
+		return c.doCached(ctx, method, path, body, result)
+	}
+	return c.send(ctx, method, path, body, result)
+}
+
+// Internal method that performs a request according to the client's retry policy.
+func (c *Client) send(ctx context.Context, method string, path string, body []byte, result envelope) error {
+	attempts := 1
+	if method == http.MethodGet || c.retry.RetryNonIdempotent {
+		attempts = c.retry.MaxAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		result.reset()
+		err := c.attempt(ctx, method, path, body, result)
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !c.retry.retryable(err) {
+			return err
+		}
+
+		delay, ok := c.retry.delay(attempt, err)
+		if !ok {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && deadline.Before(time.Now().Add(delay)) {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Internal method that performs a single request and decodes the response envelope into result.
+// Non-2xx responses are reported as *HTTPError without attempting to decode the body.
+func (c *Client) attempt(ctx context.Context, method string, path string, body []byte, result envelope) error {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, c.baseUrl+path, reader)
 	if err != nil {
-		return decoder, err
+		return err
 	}
 
-	request.Header.Add("Authorization", "Bearer "+c.Key)
-	request.Header.Add("Content-Type", "application/json")
+	c.setHeaders(request)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
 	response, err := c.client.Do(request)
 	if err != nil {
-		return decoder, err
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return &RequestError{method, path, newHTTPError(response)}
 	}
 
-	decoder = json.NewDecoder(response.Body)
-	return decoder, err
+	err = json.NewDecoder(response.Body).Decode(result)
+	// Drain what's left so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, maxDrainSize))
+	if err != nil {
+		return &RequestError{method, path, err}
+	}
+	if code := result.errorCode(); code != 0 {
+		return &RequestError{method, path, ApiError(code)}
+	}
+	return nil
 }
 
 // GetSessions retrieves all public sessions
 func (c *Client) GetSessions() ([]Session, error) {
+	return c.GetSessionsCtx(context.Background())
+}
+
+// GetSessionsCtx is like GetSessions but uses ctx for the request.
+func (c *Client) GetSessionsCtx(ctx context.Context) ([]Session, error) {
 	var result apiResponse[[]Session]
-	decoder, err := c.get("sessions")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions", &result)
 	return result.Result, err
 }
 
 // GetSession retrieves information about a session.
 func (c *Client) GetSession(sessionId string) (Session, error) {
+	return c.GetSessionCtx(context.Background(), sessionId)
+}
+
+// GetSessionCtx is like GetSession but uses ctx for the request.
+func (c *Client) GetSessionCtx(ctx context.Context, sessionId string) (Session, error) {
 	var result apiResponse[Session]
-	decoder, err := c.get("sessions/" + sessionId)
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId, &result)
 	return result.Result, err
 }
 
 // GetFlights retrieves all flights for a session.
 func (c *Client) GetFlights(sessionId string) ([]Flight, error) {
+	return c.GetFlightsCtx(context.Background(), sessionId)
+}
+
+// GetFlightsCtx is like GetFlights but uses ctx for the request.
+func (c *Client) GetFlightsCtx(ctx context.Context, sessionId string) ([]Flight, error) {
 	var result apiResponse[[]Flight]
-	decoder, err := c.get("sessions/" + sessionId + "/flights")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/flights", &result)
 	return result.Result, err
 }
 
 // GetFlight retrieves information about a specific flight in a session.
 func (c *Client) GetFlight(sessionId string, flightId string) (Flight, error) {
+	return c.GetFlightCtx(context.Background(), sessionId, flightId)
+}
+
+// GetFlightCtx is like GetFlight but uses ctx for the request.
+func (c *Client) GetFlightCtx(ctx context.Context, sessionId string, flightId string) (Flight, error) {
 	var result apiResponse[Flight]
-	decoder, err := c.get("sessions/" + sessionId + "/flights/" + flightId)
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/flights/"+flightId, &result)
 	return result.Result, err
 }
 
 // GetFlightRoute retrieves the flown path for a flight.
 func (c *Client) GetFlightRoute(sessionId string, flightId string) ([]PositionReport, error) {
+	return c.GetFlightRouteCtx(context.Background(), sessionId, flightId)
+}
+
+// GetFlightRouteCtx is like GetFlightRoute but uses ctx for the request.
+func (c *Client) GetFlightRouteCtx(ctx context.Context, sessionId string, flightId string) ([]PositionReport, error) {
 	var result apiResponse[[]PositionReport]
-	decoder, err := c.get("sessions/" + sessionId + "/flights/" + flightId + "/route")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/flights/"+flightId+"/route", &result)
 	return result.Result, err
 }
 
 // GetFlightPlan retrieves a detailed flight plan for a flight.
 func (c *Client) GetFlightPlan(sessionId string, flightId string) (FlightPlan, error) {
+	return c.GetFlightPlanCtx(context.Background(), sessionId, flightId)
+}
+
+// GetFlightPlanCtx is like GetFlightPlan but uses ctx for the request.
+func (c *Client) GetFlightPlanCtx(ctx context.Context, sessionId string, flightId string) (FlightPlan, error) {
 	var result apiResponse[FlightPlan]
-	decoder, err := c.get("sessions/" + sessionId + "/flights/" + flightId + "/flightplan")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/flights/"+flightId+"/flightplan", &result)
 	return result.Result, err
 }
 
 // GetActiveAtc retrieves all active ATC frequencies for a session.
 func (c *Client) GetActiveAtc(sessionId string) ([]ActiveAtcFacility, error) {
+	return c.GetActiveAtcCtx(context.Background(), sessionId)
+}
+
+// GetActiveAtcCtx is like GetActiveAtc but uses ctx for the request.
+func (c *Client) GetActiveAtcCtx(ctx context.Context, sessionId string) ([]ActiveAtcFacility, error) {
 	var result apiResponse[[]ActiveAtcFacility]
-	decoder, err := c.get("sessions/" + sessionId + "/atc")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/atc", &result)
 	return result.Result, err
 }
 
-// GetUserStats retrieves stats about up to 25 users at once.
+// GetUserStats retrieves stats about up to 25 users at once, see GetUserStatsBulk for more.
 func (c *Client) GetUserStats(userIds []string, usernames []string, hashes []string) ([]UserStats, error) {
+	return c.GetUserStatsCtx(context.Background(), userIds, usernames, hashes)
+}
+
+// GetUserStatsCtx is like GetUserStats but uses ctx for the request.
+func (c *Client) GetUserStatsCtx(ctx context.Context, userIds []string, usernames []string, hashes []string) ([]UserStats, error) {
 	bodyMap := map[string][]string{
 		"userIds":        userIds,
 		"discourseNames": usernames,
@@ -172,195 +253,162 @@ func (c *Client) GetUserStats(userIds []string, usernames []string, hashes []str
 	}
 	body, _ := json.Marshal(bodyMap)
 	var result apiResponse[[]UserStats]
-	decoder, err := c.post("users", bytes.NewReader(body))
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.post(ctx, "users", body, &result)
 	return result.Result, err
 }
 
 // GetUserGrade retrieves detailed grade table for a user.
 func (c *Client) GetUserGrade(userId string) (UserGrade, error) {
+	return c.GetUserGradeCtx(context.Background(), userId)
+}
+
+// GetUserGradeCtx is like GetUserGrade but uses ctx for the request.
+func (c *Client) GetUserGradeCtx(ctx context.Context, userId string) (UserGrade, error) {
 	var result apiResponse[UserGrade]
-	decoder, err := c.get("users/" + userId)
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "users/"+userId, &result)
 	return result.Result, err
 }
 
 // GetAtis retrieves ATIS for an airport in a session.
 func (c *Client) GetAtis(sessionId string, icao string) (string, error) {
+	return c.GetAtisCtx(context.Background(), sessionId, icao)
+}
+
+// GetAtisCtx is like GetAtis but uses ctx for the request.
+func (c *Client) GetAtisCtx(ctx context.Context, sessionId string, icao string) (string, error) {
 	var result apiResponse[string]
-	decoder, err := c.get("sessions/" + sessionId + "/airport/" + icao + "/atis")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/airport/"+icao+"/atis", &result)
 	return result.Result, err
 }
 
 // GetAirportStatus retrieves ATC and inbound/outbound aircraft information for an airport.
 func (c *Client) GetAirportStatus(sessionId string, icao string) (AirportStatus, error) {
+	return c.GetAirportStatusCtx(context.Background(), sessionId, icao)
+}
+
+// GetAirportStatusCtx is like GetAirportStatus but uses ctx for the request.
+func (c *Client) GetAirportStatusCtx(ctx context.Context, sessionId string, icao string) (AirportStatus, error) {
 	var result apiResponse[AirportStatus]
-	decoder, err := c.get("sessions/" + sessionId + "/airport/" + icao + "/status")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/airport/"+icao+"/status", &result)
 	return result.Result, err
 }
 
 // GetWorldStatus retrieves ATC and inbound/outbound aircraft information for all airports in a session.
 func (c *Client) GetWorldStatus(sessionId string) ([]AirportStatus, error) {
+	return c.GetWorldStatusCtx(context.Background(), sessionId)
+}
+
+// GetWorldStatusCtx is like GetWorldStatus but uses ctx for the request.
+func (c *Client) GetWorldStatusCtx(ctx context.Context, sessionId string) ([]AirportStatus, error) {
 	var result apiResponse[[]AirportStatus]
-	decoder, err := c.get("sessions/" + sessionId + "/world")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/world", &result)
 	return result.Result, err
 }
 
 // GetTracks retrieves all currently active tracks.
 func (c *Client) GetTracks() ([]Track, error) {
+	return c.GetTracksCtx(context.Background())
+}
+
+// GetTracksCtx is like GetTracks but uses ctx for the request.
+func (c *Client) GetTracksCtx(ctx context.Context) ([]Track, error) {
 	var result apiResponse[[]Track]
-	decoder, err := c.get("tracks")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "tracks", &result)
 	return result.Result, err
 }
 
 // GetUserFlights retrieves a page from the flight logbook for a user.
 func (c *Client) GetUserFlights(userId string, page int) (FlightLogbookPage, error) {
+	return c.GetUserFlightsCtx(context.Background(), userId, page)
+}
+
+// GetUserFlightsCtx is like GetUserFlights but uses ctx for the request.
+func (c *Client) GetUserFlightsCtx(ctx context.Context, userId string, page int) (FlightLogbookPage, error) {
 	var result apiResponse[FlightLogbookPage]
-	decoder, err := c.get("users/" + userId + "/flights?page=" + strconv.Itoa(page))
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "users/"+userId+"/flights?page="+strconv.Itoa(page), &result)
 	return result.Result, err
 }
 
 // GetUserFlight retrieves a flight from the user's logbook.
 func (c *Client) GetUserFlight(userId string, flightId string) (LoggedFlight, error) {
+	return c.GetUserFlightCtx(context.Background(), userId, flightId)
+}
+
+// GetUserFlightCtx is like GetUserFlight but uses ctx for the request.
+func (c *Client) GetUserFlightCtx(ctx context.Context, userId string, flightId string) (LoggedFlight, error) {
 	var result apiResponse[LoggedFlight]
-	decoder, err := c.get("users/" + userId + "/flights/" + flightId)
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "users/"+userId+"/flights/"+flightId, &result)
 	return result.Result, err
 }
 
 // GetUserAtcSessions retrieves a page from the ATC logbook for a user.
 func (c *Client) GetUserAtcSessions(userId string, page int) (AtcLogbookPage, error) {
+	return c.GetUserAtcSessionsCtx(context.Background(), userId, page)
+}
+
+// GetUserAtcSessionsCtx is like GetUserAtcSessions but uses ctx for the request.
+func (c *Client) GetUserAtcSessionsCtx(ctx context.Context, userId string, page int) (AtcLogbookPage, error) {
 	var result apiResponse[AtcLogbookPage]
-	decoder, err := c.get("users/" + userId + "/atc?page=" + strconv.Itoa(page))
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "users/"+userId+"/atc?page="+strconv.Itoa(page), &result)
 	return result.Result, err
 }
 
 // GetUserAtcSession retrieves an ATC session from the user's logbook.
 func (c *Client) GetUserAtcSession(userId string, atcSessionId string) (LoggedAtcSession, error) {
+	return c.GetUserAtcSessionCtx(context.Background(), userId, atcSessionId)
+}
+
+// GetUserAtcSessionCtx is like GetUserAtcSession but uses ctx for the request.
+func (c *Client) GetUserAtcSessionCtx(ctx context.Context, userId string, atcSessionId string) (LoggedAtcSession, error) {
 	var result apiResponse[LoggedAtcSession]
-	decoder, err := c.get("users/" + userId + "/atc/" + atcSessionId)
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "users/"+userId+"/atc/"+atcSessionId, &result)
 	return result.Result, err
 }
 
 // GetNotams retrieves NOTAMs for a session.
 func (c *Client) GetNotams(sessionId string) ([]Notam, error) {
+	return c.GetNotamsCtx(context.Background(), sessionId)
+}
+
+// GetNotamsCtx is like GetNotams but uses ctx for the request.
+func (c *Client) GetNotamsCtx(ctx context.Context, sessionId string) ([]Notam, error) {
 	var result apiResponse[[]Notam]
-	decoder, err := c.get("sessions/" + sessionId + "/notams")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "sessions/"+sessionId+"/notams", &result)
 	return result.Result, err
 }
 
 // GetAircraft retrieves all aircraft models.
 func (c *Client) GetAircraft() ([]Aircraft, error) {
+	return c.GetAircraftCtx(context.Background())
+}
+
+// GetAircraftCtx is like GetAircraft but uses ctx for the request.
+func (c *Client) GetAircraftCtx(ctx context.Context) ([]Aircraft, error) {
 	var result apiResponse[[]Aircraft]
-	decoder, err := c.get("aircraft")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "aircraft", &result)
 	return result.Result, err
 }
 
 // GetAircraftLiveries retrieves all liveries for an aircraft.
 func (c *Client) GetAircraftLiveries(aircraftId string) ([]Livery, error) {
+	return c.GetAircraftLiveriesCtx(context.Background(), aircraftId)
+}
+
+// GetAircraftLiveriesCtx is like GetAircraftLiveries but uses ctx for the request.
+func (c *Client) GetAircraftLiveriesCtx(ctx context.Context, aircraftId string) ([]Livery, error) {
 	var result apiResponse[[]Livery]
-	decoder, err := c.get("aircraft/" + aircraftId + "/liveries")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "aircraft/"+aircraftId+"/liveries", &result)
 	return result.Result, err
 }
 
 // GetLiveries retrieves all liveries.
 func (c *Client) GetLiveries() ([]Livery, error) {
+	return c.GetLiveriesCtx(context.Background())
+}
+
+// GetLiveriesCtx is like GetLiveries but uses ctx for the request.
+func (c *Client) GetLiveriesCtx(ctx context.Context) ([]Livery, error) {
 	var result apiResponse[[]Livery]
-	decoder, err := c.get("aircraft/liveries")
-	if err != nil {
-		return result.Result, err
-	}
-	err = decoder.Decode(&result)
-	if result.ErrorCode != 0 {
-		err = ApiError(result.ErrorCode)
-	}
+	err := c.get(ctx, "aircraft/liveries", &result)
 	return result.Result, err
 }
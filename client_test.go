@@ -1,14 +1,29 @@
-package golive
+package golive_test
 
 import (
 	"net/http"
 	"os"
 	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
 )
 
-func client() *Client {
-	apikey := os.Getenv("APIKEY")
-	return NewClient(apikey, &http.Client{})
+var server *golivetest.Server
+
+func TestMain(m *testing.M) {
+	server = golivetest.NewServer(nil)
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
+// client returns a client for the real Live API if APIKEY is set, or for the fake server otherwise
+func client() *golive.Client {
+	if apikey := os.Getenv("APIKEY"); apikey != "" {
+		return golive.NewClient(apikey, &http.Client{})
+	}
+	return server.Client()
 }
 
 func TestSessions(t *testing.T) {
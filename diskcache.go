@@ -0,0 +1,122 @@
+package golive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiskCache is a Cache keeping every response in a file of a directory,
+// so that it survives restarts and can be shared by processes.
+// Each file starts with the expiry time in Unix nanoseconds and the key, each on its own line,
+// followed by the value. Expired files are removed when they are looked up or invalidated.
+type DiskCache struct {
+	dir string
+}
+
+// Extension of the files written by DiskCache
+const diskCacheExt = ".golive"
+
+// NewDiskCache creates a cache in dir, creating the directory if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir}, nil
+}
+
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	file, err := os.Open(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	expires, storedKey, err := readDiskHeader(reader)
+	if err != nil || storedKey != key {
+		return nil, false
+	}
+	if time.Now().After(expires) {
+		file.Close()
+		os.Remove(d.path(key))
+		return nil, false
+	}
+	value, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set writes the entry to a temporary file renamed into place, so that readers never see it half written.
+// Write errors are ignored, the entry is then simply not cached.
+func (d *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	file, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	header := strconv.FormatInt(time.Now().Add(ttl).UnixNano(), 10) + "\n" + key + "\n"
+	_, err = file.Write(append([]byte(header), value...))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), d.path(key))
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+}
+
+func (d *DiskCache) Invalidate(prefix string) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), diskCacheExt) {
+			continue
+		}
+		name := filepath.Join(d.dir, entry.Name())
+		file, err := os.Open(name)
+		if err != nil {
+			continue
+		}
+		expires, key, err := readDiskHeader(bufio.NewReader(file))
+		file.Close()
+		if err != nil || strings.HasPrefix(key, prefix) || now.After(expires) {
+			os.Remove(name)
+		}
+	}
+}
+
+// Internal method that returns the file of a key
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+diskCacheExt)
+}
+
+// Internal function that reads the expiry time and key at the start of a file
+func readDiskHeader(reader *bufio.Reader) (time.Time, string, error) {
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	nanoseconds, err := strconv.ParseInt(string(bytes.TrimSuffix(line, []byte("\n"))), 10, 64)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	key, err := reader.ReadString('\n')
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return time.Unix(0, nanoseconds), strings.TrimSuffix(key, "\n"), nil
+}
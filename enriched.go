@@ -0,0 +1,234 @@
+package golive
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrNotInCatalog is reported by EnrichFlights for flights whose aircraft or livery the catalog does not know.
+var ErrNotInCatalog = errors.New("golive: aircraft or livery not in catalog")
+
+// Join selects the data EnrichFlights adds to flights. Joins combine with |.
+type Join int
+
+const (
+	JoinPlan Join = 1 << iota
+	JoinUser
+	JoinCatalog
+
+	JoinAll = JoinPlan | JoinUser | JoinCatalog
+)
+
+var joinNames = []struct {
+	join Join
+	name string
+}{
+	{JoinPlan, "plan"},
+	{JoinUser, "user"},
+	{JoinCatalog, "catalog"},
+}
+
+func (j Join) String() string {
+	var names []string
+	for _, n := range joinNames {
+		if j&n.join != 0 {
+			names = append(names, n.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// EnrichOptions selects and tunes the joins made by EnrichFlights.
+type EnrichOptions struct {
+	// Joins is the data to add to flights, JoinAll if zero.
+	Joins Join
+	// Catalog resolves aircraft and liveries. If nil, a catalog is refreshed for every call,
+	// so callers enriching flights repeatedly should keep their own.
+	Catalog *Catalog
+	// Concurrency is the maximum number of flight plan and user stats requests in flight, 4 if zero.
+	Concurrency int
+}
+
+// Internal function that fills in default options
+func (o *EnrichOptions) withDefaults() EnrichOptions {
+	var options EnrichOptions
+	if o != nil {
+		options = *o
+	}
+	if options.Joins == 0 {
+		options.Joins = JoinAll
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultBulkConcurrency
+	}
+	return options
+}
+
+// EnrichFailure describes a join EnrichFlights could not make for a flight.
+type EnrichFailure struct {
+	Join Join
+	Err  error
+}
+
+// EnrichedFlight is a flight along with its flight plan, the stats of its pilot and its aircraft and livery.
+// Joins that were not requested or that failed are left nil, failures are listed in Failures.
+type EnrichedFlight struct {
+	Flight   Flight
+	Plan     *FlightPlan
+	User     *UserStats
+	Aircraft *CatalogEntry
+	Failures []EnrichFailure
+}
+
+// Err returns why a join failed for the flight, or nil if it succeeded or was not requested.
+func (f EnrichedFlight) Err(join Join) error {
+	for _, failure := range f.Failures {
+		if failure.Join&join != 0 {
+			return failure.Err
+		}
+	}
+	return nil
+}
+
+// EnrichFlight retrieves a flight and joins the data selected by options to it.
+// An error is only returned if the flight cannot be retrieved, failed joins are listed in Failures.
+// A nil options uses the defaults.
+func (c *Client) EnrichFlight(ctx context.Context, sessionId string, flightId string, options *EnrichOptions) (EnrichedFlight, error) {
+	flight, err := c.GetFlightCtx(ctx, sessionId, flightId)
+	if err != nil {
+		return EnrichedFlight{}, err
+	}
+	enriched, err := c.EnrichFlights(ctx, sessionId, []Flight{flight}, options)
+	if err != nil {
+		return EnrichedFlight{}, err
+	}
+	return enriched[0], nil
+}
+
+// EnrichFlights joins the data selected by options to flights of a session, such as the result of GetFlights.
+// Flight plans are fetched concurrently, user stats in batches with GetUserStatsBulk,
+// and aircraft and liveries are resolved with the catalog, all at the same time.
+// Failed joins are listed in the Failures of each flight, an error is only returned if ctx ends.
+// The result is in the order of flights. A nil options uses the defaults.
+func (c *Client) EnrichFlights(ctx context.Context, sessionId string, flights []Flight, options *EnrichOptions) ([]EnrichedFlight, error) {
+	o := options.withDefaults()
+	var (
+		wg         sync.WaitGroup
+		plans      []*FlightPlan
+		planErrs   []error
+		users      *UserStatsBulkResult
+		catalog    = o.Catalog
+		catalogErr error
+	)
+	if o.Joins&JoinPlan != 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			plans, planErrs = c.flightPlans(ctx, sessionId, flights, o.Concurrency)
+		}()
+	}
+	if o.Joins&JoinUser != 0 {
+		var userIds []string
+		for _, flight := range flights {
+			if flight.UserId != "" {
+				userIds = append(userIds, flight.UserId)
+			}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Failed batches are listed in the failures of the result
+			users, _ = c.GetUserStatsBulk(ctx, userIds, nil, nil, &BulkOptions{Concurrency: o.Concurrency})
+		}()
+	}
+	if o.Joins&JoinCatalog != 0 && catalog == nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			catalog = NewCatalog(c)
+			catalogErr = catalog.Refresh(ctx)
+		}()
+	}
+	wg.Wait()
+
+	var userErrs map[string]error
+	if users != nil {
+		userErrs = map[string]error{}
+		for _, failure := range users.Failures {
+			userErrs[failure.Identifier] = failure.Err
+		}
+	}
+
+	result := make([]EnrichedFlight, len(flights))
+	for i, flight := range flights {
+		enriched := &result[i]
+		enriched.Flight = flight
+		fail := func(join Join, err error) {
+			enriched.Failures = append(enriched.Failures, EnrichFailure{join, err})
+		}
+
+		if o.Joins&JoinPlan != 0 {
+			if planErrs[i] != nil {
+				fail(JoinPlan, planErrs[i])
+			} else {
+				enriched.Plan = plans[i]
+			}
+		}
+
+		if o.Joins&JoinUser != 0 {
+			if stats, ok := users.ByUserId[flight.UserId]; ok {
+				enriched.User = &stats
+			} else if err := userErrs[flight.UserId]; err != nil {
+				fail(JoinUser, err)
+			} else {
+				fail(JoinUser, ErrUserNotFound)
+			}
+		}
+
+		if o.Joins&JoinCatalog != 0 {
+			if catalogErr != nil {
+				fail(JoinCatalog, catalogErr)
+			} else if entry, ok := catalog.ResolveFlight(flight); ok {
+				enriched.Aircraft = &entry
+			} else {
+				fail(JoinCatalog, ErrNotInCatalog)
+			}
+		}
+	}
+	return result, ctx.Err()
+}
+
+// Internal method that fetches the flight plans of flights, at most concurrency at a time
+func (c *Client) flightPlans(ctx context.Context, sessionId string, flights []Flight, concurrency int) ([]*FlightPlan, []error) {
+	plans := make([]*FlightPlan, len(flights))
+	errs := make([]error, len(flights))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrency)
+	for i := range flights {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+
+			plan, err := c.GetFlightPlanCtx(ctx, sessionId, flights[i].Id)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			plans[i] = &plan
+		}(i)
+	}
+	wg.Wait()
+	return plans, errs
+}
@@ -0,0 +1,102 @@
+package golive_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func TestEnrichFlights(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+	flights := golivetest.DefaultFixtures().Flights[golivetest.ExpertSessionId]
+
+	enriched, err := client.EnrichFlights(context.Background(), golivetest.ExpertSessionId, flights, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enriched) != len(flights) {
+		t.Fatalf("expected %d flights, got %d", len(flights), len(enriched))
+	}
+	for i, flight := range enriched {
+		if flight.Flight.Id != flights[i].Id {
+			t.Errorf("flight %d is %s, expected %s", i, flight.Flight.Id, flights[i].Id)
+		}
+		if flight.Aircraft == nil {
+			t.Errorf("%s: expected the aircraft to be resolved, got %+v", flight.Flight.Callsign, flight.Failures)
+		}
+	}
+
+	delta := enriched[0]
+	if len(delta.Failures) != 0 {
+		t.Errorf("unexpected failures %+v", delta.Failures)
+	}
+	if delta.Plan == nil || delta.Plan.FlightId != golivetest.DeltaFlightId {
+		t.Errorf("unexpected plan %+v", delta.Plan)
+	}
+	if delta.User == nil || delta.User.DiscourseUsername != "KaiM" {
+		t.Errorf("unexpected user %+v", delta.User)
+	}
+	if name := delta.Aircraft.Name(); name != "Airbus A320 – Delta" {
+		t.Errorf("unexpected aircraft %q", name)
+	}
+
+	// Only the Delta flight has a plan, and the Southwest pilot has no stats
+	british, southwest := enriched[1], enriched[2]
+	if british.Plan != nil || !errors.Is(british.Err(golive.JoinPlan), golive.ErrFlightNotFound) {
+		t.Errorf("expected the plan to be missing, got %+v", british.Failures)
+	}
+	if british.User == nil || british.Err(golive.JoinUser) != nil {
+		t.Errorf("unexpected user failure %+v", british.Failures)
+	}
+	if southwest.User != nil || !errors.Is(southwest.Err(golive.JoinUser), golive.ErrUserNotFound) {
+		t.Errorf("expected the user to be missing, got %+v", southwest.Failures)
+	}
+}
+
+func TestEnrichFlightJoins(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+	catalog := golive.NewCatalog(client)
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	before := len(server.Requests())
+
+	options := &golive.EnrichOptions{Joins: golive.JoinPlan | golive.JoinCatalog, Catalog: catalog}
+	flight, err := client.EnrichFlight(context.Background(), golivetest.ExpertSessionId, golivetest.DeltaFlightId, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flight.Plan == nil || flight.Aircraft == nil || flight.User != nil || len(flight.Failures) != 0 {
+		t.Errorf("unexpected joins %+v", flight)
+	}
+	// The flight and its plan, the catalog is not refreshed and users are not requested
+	if requests := server.Requests()[before:]; len(requests) != 2 {
+		t.Errorf("expected 2 requests, got %v", requests)
+	}
+
+	if _, err := client.EnrichFlight(context.Background(), golivetest.ExpertSessionId, "unknown", nil); !errors.Is(err, golive.ErrFlightNotFound) {
+		t.Errorf("expected ErrFlightNotFound, got %v", err)
+	}
+
+	server.Inject(golivetest.Fault{Path: "aircraft", Status: http.StatusNotFound})
+	flight, err = client.EnrichFlight(context.Background(), golivetest.ExpertSessionId, golivetest.DeltaFlightId, &golive.EnrichOptions{Joins: golive.JoinCatalog})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var httpErr *golive.HTTPError
+	if flight.Aircraft != nil || !errors.As(flight.Err(golive.JoinCatalog), &httpErr) {
+		t.Errorf("expected the catalog to fail, got %+v", flight.Failures)
+	}
+
+	if s := (golive.JoinPlan | golive.JoinCatalog).String(); s != "plan|catalog" {
+		t.Errorf("unexpected join name %q", s)
+	}
+}
@@ -0,0 +1,254 @@
+package golive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Enumerations decode from the API's integers and encode back to them in JSON.
+// As text they use their names, values without a name are written as Type(n)
+// so that unknown values survive a round trip.
+
+////// SESSION TYPE
+
+// SessionType tells whether a session has entry requirements.
+type SessionType int
+
+const (
+	SessionRestricted   SessionType = 0
+	SessionUnrestricted SessionType = 1
+)
+
+var sessionTypeNames = map[int]string{
+	0: "Restricted",
+	1: "Unrestricted",
+}
+
+func (t SessionType) String() string {
+	return enumString("SessionType", sessionTypeNames, int(t))
+}
+
+func (t SessionType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *SessionType) UnmarshalText(b []byte) error {
+	return enumUnmarshalText("SessionType", sessionTypeNames, b, (*int)(t))
+}
+
+func (t SessionType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(t))
+}
+
+func (t *SessionType) UnmarshalJSON(b []byte) error {
+	return enumUnmarshalJSON("SessionType", sessionTypeNames, b, (*int)(t))
+}
+
+////// FACILITY TYPE
+
+// FacilityType is the kind of an ATC frequency.
+type FacilityType int
+
+const (
+	FacilityGround    FacilityType = 0
+	FacilityTower     FacilityType = 1
+	FacilityUnicom    FacilityType = 2
+	FacilityClearance FacilityType = 3
+	FacilityApproach  FacilityType = 4
+	FacilityDeparture FacilityType = 5
+	FacilityCenter    FacilityType = 6
+	FacilityAtis      FacilityType = 7
+	FacilityAircraft  FacilityType = 8
+	FacilityRecorded  FacilityType = 9
+	FacilityUnknown   FacilityType = 10
+	FacilityUnused    FacilityType = 11
+)
+
+var facilityTypeNames = map[int]string{
+	0:  "Ground",
+	1:  "Tower",
+	2:  "Unicom",
+	3:  "Clearance",
+	4:  "Approach",
+	5:  "Departure",
+	6:  "Center",
+	7:  "ATIS",
+	8:  "Aircraft",
+	9:  "Recorded",
+	10: "Unknown",
+	11: "Unused",
+}
+
+func (t FacilityType) String() string {
+	return enumString("FacilityType", facilityTypeNames, int(t))
+}
+
+func (t FacilityType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *FacilityType) UnmarshalText(b []byte) error {
+	return enumUnmarshalText("FacilityType", facilityTypeNames, b, (*int)(t))
+}
+
+func (t FacilityType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(t))
+}
+
+func (t *FacilityType) UnmarshalJSON(b []byte) error {
+	return enumUnmarshalJSON("FacilityType", facilityTypeNames, b, (*int)(t))
+}
+
+////// NOTAM TYPE
+
+// NotamType is the kind of a NOTAM.
+// The Live API does not document its values, so none are named.
+type NotamType int
+
+var notamTypeNames = map[int]string{}
+
+func (t NotamType) String() string {
+	return enumString("NotamType", notamTypeNames, int(t))
+}
+
+func (t NotamType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *NotamType) UnmarshalText(b []byte) error {
+	return enumUnmarshalText("NotamType", notamTypeNames, b, (*int)(t))
+}
+
+func (t NotamType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(t))
+}
+
+func (t *NotamType) UnmarshalJSON(b []byte) error {
+	return enumUnmarshalJSON("NotamType", notamTypeNames, b, (*int)(t))
+}
+
+////// FLIGHT PLAN ITEM TYPE
+
+// FlightPlanItemType tells procedures apart from other flight plan items.
+type FlightPlanItemType int
+
+const (
+	FlightPlanItemSid      FlightPlanItemType = 0
+	FlightPlanItemStar     FlightPlanItemType = 1
+	FlightPlanItemApproach FlightPlanItemType = 2
+	FlightPlanItemTrack    FlightPlanItemType = 3
+	FlightPlanItemUnknown  FlightPlanItemType = 5
+)
+
+var flightPlanItemTypeNames = map[int]string{
+	0: "SID",
+	1: "STAR",
+	2: "Approach",
+	3: "Track",
+	5: "Unknown",
+}
+
+func (t FlightPlanItemType) String() string {
+	return enumString("FlightPlanItemType", flightPlanItemTypeNames, int(t))
+}
+
+func (t FlightPlanItemType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *FlightPlanItemType) UnmarshalText(b []byte) error {
+	return enumUnmarshalText("FlightPlanItemType", flightPlanItemTypeNames, b, (*int)(t))
+}
+
+func (t FlightPlanItemType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(t))
+}
+
+func (t *FlightPlanItemType) UnmarshalJSON(b []byte) error {
+	return enumUnmarshalJSON("FlightPlanItemType", flightPlanItemTypeNames, b, (*int)(t))
+}
+
+////// ROLE
+
+// Role is a community role held by a user.
+type Role int
+
+const (
+	RoleStaff     Role = 1
+	RoleModerator Role = 2
+	RoleIfatc     Role = 64
+)
+
+var roleNames = map[int]string{
+	1:  "Staff",
+	2:  "Moderator",
+	64: "IFATC",
+}
+
+func (r Role) String() string {
+	return enumString("Role", roleNames, int(r))
+}
+
+func (r Role) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Role) UnmarshalText(b []byte) error {
+	return enumUnmarshalText("Role", roleNames, b, (*int)(r))
+}
+
+func (r Role) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(r))
+}
+
+func (r *Role) UnmarshalJSON(b []byte) error {
+	return enumUnmarshalJSON("Role", roleNames, b, (*int)(r))
+}
+
+////// HELPERS
+
+// Internal function that names an enumeration value, or formats it as Type(n) if it has no name
+func enumString(typeName string, names map[int]string, value int) string {
+	if name, ok := names[value]; ok {
+		return name
+	}
+	return typeName + "(" + strconv.Itoa(value) + ")"
+}
+
+// Internal function that parses a name, Type(n) or a bare integer into value
+func enumUnmarshalText(typeName string, names map[int]string, b []byte, value *int) error {
+	text := strings.TrimSpace(string(b))
+	for v, name := range names {
+		if strings.EqualFold(name, text) {
+			*value = v
+			return nil
+		}
+	}
+	if strings.HasPrefix(text, typeName+"(") && strings.HasSuffix(text, ")") {
+		text = text[len(typeName)+1 : len(text)-1]
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return fmt.Errorf("golive: invalid %s %q", typeName, b)
+	}
+	*value = v
+	return nil
+}
+
+// Internal function that decodes a JSON integer, or a string in any form accepted by enumUnmarshalText
+func enumUnmarshalJSON(typeName string, names map[int]string, b []byte, value *int) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var text string
+		if err := json.Unmarshal(b, &text); err != nil {
+			return err
+		}
+		return enumUnmarshalText(typeName, names, []byte(text), value)
+	}
+	return json.Unmarshal(b, value)
+}
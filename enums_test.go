@@ -0,0 +1,63 @@
+package golive
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumJSON(t *testing.T) {
+	var facility ActiveAtcFacility
+	if err := json.Unmarshal([]byte(`{"type":4}`), &facility); err != nil {
+		t.Fatal(err)
+	}
+	if facility.Type != FacilityApproach || facility.Type.String() != "Approach" {
+		t.Errorf("unexpected facility type %v", facility.Type)
+	}
+
+	var stats UserStats
+	if err := json.Unmarshal([]byte(`{"roles":[1,64,512]}`), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Roles) != 3 || stats.Roles[1] != RoleIfatc || stats.Roles[2].String() != "Role(512)" {
+		t.Errorf("unexpected roles %v", stats.Roles)
+	}
+
+	// Encoding keeps the API's integers, including for unknown values
+	b, err := json.Marshal(stats.Roles)
+	if err != nil || string(b) != "[1,64,512]" {
+		t.Errorf("unexpected encoding %s, %v", b, err)
+	}
+}
+
+func TestEnumText(t *testing.T) {
+	for _, value := range []FacilityType{FacilityGround, FacilityAtis, FacilityType(42)} {
+		text, err := value.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded FacilityType
+		if err := decoded.UnmarshalText(text); err != nil || decoded != value {
+			t.Errorf("%v: round trip through %q gave %v, %v", value, text, decoded, err)
+		}
+	}
+
+	// Strings are accepted in JSON as well, names case-insensitively
+	var items []FlightPlanItemType
+	if err := json.Unmarshal([]byte(`["sid","FlightPlanItemType(4)","2",1]`), &items); err != nil {
+		t.Fatal(err)
+	}
+	expected := []FlightPlanItemType{FlightPlanItemSid, 4, FlightPlanItemApproach, FlightPlanItemStar}
+	for i := range expected {
+		if items[i] != expected[i] {
+			t.Errorf("item %d: expected %v, got %v", i, expected[i], items[i])
+		}
+	}
+
+	var session SessionType
+	if err := session.UnmarshalText([]byte("Expert")); err == nil {
+		t.Error("expected error for unknown name")
+	}
+	if NotamType(3).String() != "NotamType(3)" {
+		t.Errorf("unexpected NOTAM type name %q", NotamType(3).String())
+	}
+}
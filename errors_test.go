@@ -0,0 +1,113 @@
+package golive
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// closeTracker counts response bodies that were closed by the client.
+type closeTracker struct {
+	opened, closed int32
+}
+
+type trackedBody struct {
+	io.ReadCloser
+	tracker *closeTracker
+}
+
+func (b trackedBody) Close() error {
+	atomic.AddInt32(&b.tracker.closed, 1)
+	return b.ReadCloser.Close()
+}
+
+func (t *closeTracker) RoundTrip(r *http.Request) (*http.Response, error) {
+	response, err := http.DefaultTransport.RoundTrip(r)
+	if err == nil {
+		atomic.AddInt32(&t.opened, 1)
+		response.Body = trackedBody{response.Body, t}
+	}
+	return response, err
+}
+
+func TestHTTPError(t *testing.T) {
+	page := "<html>" + strings.Repeat("bad gateway ", 200) + "</html>"
+	tracker := &closeTracker{}
+	c := fakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(page))
+	}, WithHTTPClient(&http.Client{Transport: tracker}))
+
+	_, err := c.GetWorldStatus("s1")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("expected *HTTPError, got %v", err)
+	}
+	if httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("unexpected status code %d", httpErr.StatusCode)
+	}
+	if httpErr.Header.Get("Content-Type") != "text/html" {
+		t.Errorf("unexpected headers %v", httpErr.Header)
+	}
+	if len(httpErr.Body) != maxErrorBodySize || !strings.HasPrefix(page, string(httpErr.Body)) {
+		t.Errorf("unexpected body of length %d", len(httpErr.Body))
+	}
+	if !strings.Contains(err.Error(), "sessions/s1/world") {
+		t.Errorf("expected path in error message, got %q", err)
+	}
+	if tracker.opened != 1 || tracker.closed != 1 {
+		t.Errorf("opened %d bodies, closed %d", tracker.opened, tracker.closed)
+	}
+}
+
+func TestApiErrorAfterStatus(t *testing.T) {
+	tracker := &closeTracker{}
+	c := fakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errorCode":6,"result":null}`))
+	}, WithHTTPClient(&http.Client{Transport: tracker}))
+
+	_, err := c.GetFlight("s1", "f1")
+	var apiErr ApiError
+	if !errors.As(err, &apiErr) || apiErr != 6 {
+		t.Errorf("expected ApiError 6, got %v", err)
+	}
+	if tracker.opened != 1 || tracker.closed != 1 {
+		t.Errorf("opened %d bodies, closed %d", tracker.opened, tracker.closed)
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	c := fakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/public/v2/sessions/s1/airport/KLAX/atis":
+			w.Write([]byte(`{"errorCode":7,"result":null}`))
+		case "/public/v2/users/u1":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.Write([]byte(`{"errorCode":6,"result":null}`))
+		}
+	})
+
+	_, err := c.GetFlightPlan("s1", "f1")
+	if !errors.Is(err, ErrFlightNotFound) || errors.Is(err, ErrNoAtis) {
+		t.Errorf("expected ErrFlightNotFound, got %v", err)
+	}
+	var requestErr *RequestError
+	if !errors.As(err, &requestErr) || requestErr.Method != "GET" || requestErr.Path != "sessions/s1/flights/f1/flightplan" {
+		t.Errorf("unexpected request error %#v", requestErr)
+	}
+
+	_, err = c.GetAtis("s1", "KLAX")
+	if !errors.Is(err, ErrNoAtis) {
+		t.Errorf("expected ErrNoAtis, got %v", err)
+	}
+
+	_, err = c.GetUserGrade("u1")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAiracCycle(t *testing.T) {
+	tests := []struct {
+		date  string
+		cycle int
+	}{
+		{"2020-01-02", 2001},
+		{"2020-12-30", 2013},
+		// 2020 had 14 cycles
+		{"2020-12-31", 2014},
+		{"2021-01-28", 2101},
+		{"2022-10-01", 2209},
+		{"2022-10-06", 2210},
+		{"2019-12-31", 1913},
+	}
+	for _, test := range tests {
+		date, _ := time.Parse("2006-01-02", test.date)
+		if cycle := airacCycle(date); cycle != test.cycle {
+			t.Errorf("%s: expected cycle %d, got %d", test.date, test.cycle, cycle)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package export writes Infinite Flight data in map and flight simulator formats.
+//
+// Writers stream their output and return the first write error.
+// Altitudes are converted from feet to meters and speeds from knots to meters per second
+// wherever a format expects SI units.
+package export
+
+import (
+	"encoding/xml"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/geo"
+)
+
+// Unit conversions
+const (
+	metersPerFoot          = 0.3048
+	metersPerSecondPerKnot = geo.MetersPerNauticalMile / 3600
+)
+
+// Internal function that formats a coordinate with at most 7 decimals, about a centimetre
+func coordinate(degrees float64) string {
+	return number(degrees, 7)
+}
+
+// Internal function that converts feet to meters, rounded to the centimetre
+func meters(feet float64) string {
+	return number(feet*metersPerFoot, 2)
+}
+
+// Internal function that converts knots to meters per second
+func metersPerSecond(knots float64) string {
+	return number(knots*metersPerSecondPerKnot, 2)
+}
+
+// Internal function that formats a number rounded to a number of decimals, without trailing zeros
+func number(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	value = math.Round(value*scale) / scale
+	if value == 0 {
+		// Avoid negative zero
+		value = 0
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Internal function that formats a timestamp in UTC
+func timestamp(t golive.Time) string {
+	return t.Time().UTC().Format(time.RFC3339Nano)
+}
+
+// Internal function that escapes text for XML content and attributes
+func escape(text string) string {
+	var builder strings.Builder
+	xml.EscapeText(&builder, []byte(text))
+	return builder.String()
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8"?>` + "\n"
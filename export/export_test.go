@@ -0,0 +1,95 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/export"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// Internal function that compares output with testdata/name, or rewrites it with -update
+func golden(t *testing.T, name string, output []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, output, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(output, expected) {
+		t.Errorf("%s differs from the golden file, run go test ./export -update if the change is intended\n%s", name, output)
+	}
+}
+
+// Internal function that fails unless output is well-formed XML
+func wellFormed(t *testing.T, output []byte) {
+	t.Helper()
+	decoder := xml.NewDecoder(bytes.NewReader(output))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("malformed XML: %v", err)
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRoute(t *testing.T) {
+	route := golivetest.DefaultFixtures().Routes[golivetest.DeltaFlightId]
+	name := "Delta 123 <KLAX-KSFO>"
+
+	writers := []struct {
+		file  string
+		write func(io.Writer, string, []golive.PositionReport) error
+	}{
+		{"route.gpx", export.WriteRouteGPX},
+		{"route.kml", export.WriteRouteKML},
+		{"route.geojson", export.WriteRouteGeoJSON},
+	}
+	for _, writer := range writers {
+		var buffer bytes.Buffer
+		if err := writer.write(&buffer, name, route); err != nil {
+			t.Fatalf("%s: %v", writer.file, err)
+		}
+		if filepath.Ext(writer.file) == ".geojson" {
+			if !json.Valid(buffer.Bytes()) {
+				t.Errorf("%s: invalid JSON", writer.file)
+			}
+		} else {
+			wellFormed(t, buffer.Bytes())
+		}
+		golden(t, writer.file, buffer.Bytes())
+
+		if err := writer.write(failingWriter{}, name, route); err == nil {
+			t.Errorf("%s: expected the write error", writer.file)
+		}
+		buffer.Reset()
+		if err := writer.write(&buffer, name, nil); err != nil {
+			t.Errorf("%s: empty route: %v", writer.file, err)
+		}
+	}
+}
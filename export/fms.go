@@ -0,0 +1,83 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// WritePlanFMS writes a flight plan in the X-Plane 11 .fms format.
+// Every waypoint is flown direct, with its altitude constraint in feet or zero if it has none,
+// and the SID, STAR and approach are named in the header.
+// The AIRAC cycle is the one in effect when the plan was last updated.
+// The Live API does not give the kind of waypoints, so it is guessed from their identifiers:
+// runways are latitudes and longitudes, three letters or fewer are VORs, five letters are fixes
+// and anything else is a latitude and longitude.
+func WritePlanFMS(w io.Writer, plan golive.FlightPlan) error {
+	points := plan.Flatten()
+	departure, destination := airports(points)
+	updated := plan.LastUpdate.Time()
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	out := bufio.NewWriter(w)
+	out.WriteString("I\n")
+	out.WriteString("1100 Version\n")
+	out.WriteString("CYCLE " + strconv.Itoa(airacCycle(updated)) + "\n")
+	if departure != nil {
+		out.WriteString("ADEP " + departure.Identifier + "\n")
+	}
+	if sid, ok := plan.Procedure(golive.FlightPlanItemSid); ok {
+		out.WriteString("SID " + sid.Identifier + "\n")
+	}
+	if star, ok := plan.Procedure(golive.FlightPlanItemStar); ok {
+		out.WriteString("STAR " + star.Identifier + "\n")
+	}
+	if approach, ok := plan.Procedure(golive.FlightPlanItemApproach); ok {
+		out.WriteString("APP " + approach.Identifier + "\n")
+	}
+	if destination != nil {
+		out.WriteString("ADES " + destination.Identifier + "\n")
+	}
+
+	out.WriteString("NUMENR " + strconv.Itoa(len(points)) + "\n")
+	for i, point := range points {
+		end := i == 0 || i == len(points)-1
+		kind := kindOf(point, end)
+		code, via, altitude := "28", "DRCT", float64(constraint(point))
+		switch kind {
+		case kindAirport:
+			code, altitude = "1", point.Location.Altitude
+			if i == 0 {
+				via = "ADEP"
+			} else {
+				via = "ADES"
+			}
+		case kindVor:
+			code = "3"
+		case kindFix:
+			code = "11"
+		}
+		out.WriteString(code + " " + fmsIdentifier(point) + " " + via + " " +
+			strconv.FormatFloat(altitude, 'f', 6, 64) + " " +
+			strconv.FormatFloat(point.Location.Latitude, 'f', 6, 64) + " " +
+			strconv.FormatFloat(point.Location.Longitude, 'f', 6, 64) + "\n")
+	}
+	return out.Flush()
+}
+
+// Internal function that returns an identifier without spaces, which would break the line into more fields
+func fmsIdentifier(point golive.FlightPlanWaypoint) string {
+	identifier := point.Identifier
+	if identifier == "" {
+		identifier = point.Name
+	}
+	if identifier == "" || !isAlphanumeric(identifier) {
+		return "WPT"
+	}
+	return identifier
+}
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// WriteRouteGeoJSON writes a route from GetFlightRoute as a GeoJSON FeatureCollection.
+// The first feature is the route as a LineString named name, followed by a Point for every report
+// with its altitude in feet, ground speed in knots, track and date as properties.
+// Coordinates hold the altitude in meters, as GeoJSON readers expect.
+func WriteRouteGeoJSON(w io.Writer, name string, route []golive.PositionReport) error {
+	out := bufio.NewWriter(w)
+	out.WriteString(`{"type":"FeatureCollection","features":[` + "\n")
+
+	out.WriteString(`{"type":"Feature","properties":{"name":` + jsonString(name) + `},"geometry":{"type":"LineString","coordinates":[`)
+	for i, report := range route {
+		if i > 0 {
+			out.WriteString(",")
+		}
+		out.WriteString(position(report.Latitude, report.Longitude, report.Altitude))
+	}
+	out.WriteString("]}}")
+
+	for _, report := range route {
+		out.WriteString(",\n")
+		out.WriteString(`{"type":"Feature","properties":{`)
+		out.WriteString(`"altitude":` + number(report.Altitude, 2))
+		out.WriteString(`,"groundSpeed":` + number(report.GroundSpeed, 2))
+		out.WriteString(`,"track":` + number(report.Track, 2))
+		if !report.Date.IsZero() {
+			out.WriteString(`,"date":"` + timestamp(report.Date) + `"`)
+		}
+		out.WriteString(`},"geometry":{"type":"Point","coordinates":` + position(report.Latitude, report.Longitude, report.Altitude) + "}}")
+	}
+	out.WriteString("\n]}\n")
+	return out.Flush()
+}
+
+// Internal function that formats a GeoJSON position with the altitude in meters
+func position(latitude float64, longitude float64, altitude float64) string {
+	return "[" + coordinate(longitude) + "," + coordinate(latitude) + "," + meters(altitude) + "]"
+}
+
+// Internal function that encodes a JSON string
+func jsonString(text string) string {
+	var builder strings.Builder
+	encoder := json.NewEncoder(&builder)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(text)
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// WritePlanGeoJSON writes a flight plan as a GeoJSON FeatureCollection.
+// The first feature is the planned route as a LineString named name, followed by a Point for every waypoint
+// with its identifier, the procedure it belongs to and its altitude constraint in feet, if any, as properties.
+func WritePlanGeoJSON(w io.Writer, name string, plan golive.FlightPlan) error {
+	points := plan.Flatten()
+	out := bufio.NewWriter(w)
+	out.WriteString(`{"type":"FeatureCollection","features":[` + "\n")
+
+	out.WriteString(`{"type":"Feature","properties":{"name":` + jsonString(name))
+	out.WriteString(`,"flightPlanId":` + jsonString(plan.Id) + `,"flightId":` + jsonString(plan.FlightId))
+	out.WriteString(`},"geometry":{"type":"LineString","coordinates":[`)
+	for i, point := range points {
+		if i > 0 {
+			out.WriteString(",")
+		}
+		out.WriteString(planPosition(point))
+	}
+	out.WriteString("]}}")
+
+	for _, point := range points {
+		out.WriteString(",\n")
+		out.WriteString(`{"type":"Feature","properties":{"identifier":` + jsonString(point.Identifier))
+		if point.Name != point.Identifier {
+			out.WriteString(`,"name":` + jsonString(point.Name))
+		}
+		if point.Procedure != nil {
+			out.WriteString(`,"procedure":` + jsonString(point.Procedure.Identifier) + `,"procedureType":` + jsonString(point.Procedure.Type.String()))
+		}
+		if constraint := constraint(point); constraint > 0 {
+			out.WriteString(`,"altitudeConstraint":` + number(float64(constraint), 0))
+		}
+		out.WriteString(`},"geometry":{"type":"Point","coordinates":` + planPosition(point) + "}}")
+	}
+	out.WriteString("\n]}\n")
+	return out.Flush()
+}
+
+// Internal function that formats the GeoJSON position of a waypoint,
+// with its altitude constraint or elevation in meters if it has either
+func planPosition(point golive.FlightPlanWaypoint) string {
+	altitude := point.Location.Altitude
+	if constraint := constraint(point); constraint > 0 {
+		altitude = float64(constraint)
+	}
+	if altitude == 0 {
+		return "[" + coordinate(point.Location.Longitude) + "," + coordinate(point.Location.Latitude) + "]"
+	}
+	return position(point.Location.Latitude, point.Location.Longitude, altitude)
+}
@@ -0,0 +1,39 @@
+package export
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// WriteRouteGPX writes a route from GetFlightRoute as a GPX 1.1 track named name.
+// Ground speed and track are written with the Garmin TrackPointExtension,
+// which most GPX readers understand.
+func WriteRouteGPX(w io.Writer, name string, route []golive.PositionReport) error {
+	out := bufio.NewWriter(w)
+	out.WriteString(xmlHeader)
+	out.WriteString(`<gpx version="1.1" creator="golive" xmlns="http://www.topografix.com/GPX/1/1"` +
+		` xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v2">` + "\n")
+	out.WriteString("  <trk>\n")
+	out.WriteString("    <name>" + escape(name) + "</name>\n")
+	out.WriteString("    <trkseg>\n")
+	for _, report := range route {
+		out.WriteString(`      <trkpt lat="` + coordinate(report.Latitude) + `" lon="` + coordinate(report.Longitude) + `">` + "\n")
+		out.WriteString("        <ele>" + meters(report.Altitude) + "</ele>\n")
+		if !report.Date.IsZero() {
+			out.WriteString("        <time>" + timestamp(report.Date) + "</time>\n")
+		}
+		out.WriteString("        <extensions>\n")
+		out.WriteString("          <gpxtpx:TrackPointExtension>\n")
+		out.WriteString("            <gpxtpx:speed>" + metersPerSecond(report.GroundSpeed) + "</gpxtpx:speed>\n")
+		out.WriteString("            <gpxtpx:course>" + number(report.Track, 2) + "</gpxtpx:course>\n")
+		out.WriteString("          </gpxtpx:TrackPointExtension>\n")
+		out.WriteString("        </extensions>\n")
+		out.WriteString("      </trkpt>\n")
+	}
+	out.WriteString("    </trkseg>\n")
+	out.WriteString("  </trk>\n")
+	out.WriteString("</gpx>\n")
+	return out.Flush()
+}
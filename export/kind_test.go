@@ -0,0 +1,31 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		identifier string
+		end        bool
+		kind       waypointKind
+	}{
+		{"KSFO", true, kindAirport},
+		{"KSFO", false, kindUser},
+		{"RZS", false, kindVor},
+		{"SERFR", false, kindFix},
+		{"RW28R", false, kindUser},
+		{"RW09", true, kindUser},
+		{"RW1", false, kindUser},
+		{"RWAYS", false, kindFix},
+		{"RW28X", false, kindFix},
+	}
+	for _, test := range tests {
+		point := golive.FlightPlanWaypoint{FlightPlanItem: golive.FlightPlanItem{Identifier: test.identifier}}
+		if kind := kindOf(point, test.end); kind != test.kind {
+			t.Errorf("%s: expected kind %d, got %d", test.identifier, test.kind, kind)
+		}
+	}
+}
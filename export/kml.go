@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// WriteRouteKML writes a route from GetFlightRoute as a KML document named name.
+// It holds a line extruded down to the ground at the reported altitudes,
+// and a timed gx:Track with the ground speed of every report for playback in Google Earth.
+func WriteRouteKML(w io.Writer, name string, route []golive.PositionReport) error {
+	out := bufio.NewWriter(w)
+	out.WriteString(xmlHeader)
+	out.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2" xmlns:gx="http://www.google.com/kml/ext/2.2">` + "\n")
+	out.WriteString("  <Document>\n")
+	out.WriteString("    <name>" + escape(name) + "</name>\n")
+	out.WriteString(`    <Schema id="report">` + "\n")
+	out.WriteString(`      <gx:SimpleArrayField name="groundSpeed" type="float">` + "\n")
+	out.WriteString("        <displayName>Ground speed (kt)</displayName>\n")
+	out.WriteString("      </gx:SimpleArrayField>\n")
+	out.WriteString("    </Schema>\n")
+
+	out.WriteString("    <Placemark>\n")
+	out.WriteString("      <name>" + escape(name) + "</name>\n")
+	if len(route) > 0 && !route[0].Date.IsZero() && !route[len(route)-1].Date.IsZero() {
+		out.WriteString("      <TimeSpan>\n")
+		out.WriteString("        <begin>" + timestamp(route[0].Date) + "</begin>\n")
+		out.WriteString("        <end>" + timestamp(route[len(route)-1].Date) + "</end>\n")
+		out.WriteString("      </TimeSpan>\n")
+	}
+	out.WriteString("      <LineString>\n")
+	out.WriteString("        <extrude>1</extrude>\n")
+	out.WriteString("        <tessellate>1</tessellate>\n")
+	out.WriteString("        <altitudeMode>absolute</altitudeMode>\n")
+	out.WriteString("        <coordinates>\n")
+	for _, report := range route {
+		out.WriteString("          " + coordinate(report.Longitude) + "," + coordinate(report.Latitude) + "," + meters(report.Altitude) + "\n")
+	}
+	out.WriteString("        </coordinates>\n")
+	out.WriteString("      </LineString>\n")
+	out.WriteString("    </Placemark>\n")
+
+	out.WriteString("    <Placemark>\n")
+	out.WriteString("      <name>" + escape(name) + " track</name>\n")
+	out.WriteString("      <gx:Track>\n")
+	out.WriteString("        <altitudeMode>absolute</altitudeMode>\n")
+	for _, report := range route {
+		// gx:Track needs a when for every coord, an empty one means unknown
+		when := ""
+		if !report.Date.IsZero() {
+			when = timestamp(report.Date)
+		}
+		out.WriteString("        <when>" + when + "</when>\n")
+	}
+	for _, report := range route {
+		out.WriteString("        <gx:coord>" + coordinate(report.Longitude) + " " + coordinate(report.Latitude) + " " + meters(report.Altitude) + "</gx:coord>\n")
+	}
+	out.WriteString("        <ExtendedData>\n")
+	out.WriteString(`          <SchemaData schemaUrl="#report">` + "\n")
+	out.WriteString(`            <gx:SimpleArrayData name="groundSpeed">` + "\n")
+	for _, report := range route {
+		out.WriteString("              <gx:value>" + number(report.GroundSpeed, 2) + "</gx:value>\n")
+	}
+	out.WriteString("            </gx:SimpleArrayData>\n")
+	out.WriteString("          </SchemaData>\n")
+	out.WriteString("        </ExtendedData>\n")
+	out.WriteString("      </gx:Track>\n")
+	out.WriteString("    </Placemark>\n")
+	out.WriteString("  </Document>\n")
+	out.WriteString("</kml>\n")
+	return out.Flush()
+}
+
+// WritePlanKML writes a flight plan as a KML document named name.
+// It holds the planned route as a line clamped to the ground and a placemark for every waypoint,
+// described with the procedure it belongs to and its altitude constraint, if any.
+func WritePlanKML(w io.Writer, name string, plan golive.FlightPlan) error {
+	points := plan.Flatten()
+	out := bufio.NewWriter(w)
+	out.WriteString(xmlHeader)
+	out.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2">` + "\n")
+	out.WriteString("  <Document>\n")
+	out.WriteString("    <name>" + escape(name) + "</name>\n")
+
+	out.WriteString("    <Placemark>\n")
+	out.WriteString("      <name>" + escape(name) + "</name>\n")
+	out.WriteString("      <LineString>\n")
+	out.WriteString("        <tessellate>1</tessellate>\n")
+	out.WriteString("        <altitudeMode>clampToGround</altitudeMode>\n")
+	out.WriteString("        <coordinates>\n")
+	for _, point := range points {
+		out.WriteString("          " + coordinate(point.Location.Longitude) + "," + coordinate(point.Location.Latitude) + "\n")
+	}
+	out.WriteString("        </coordinates>\n")
+	out.WriteString("      </LineString>\n")
+	out.WriteString("    </Placemark>\n")
+
+	out.WriteString("    <Folder>\n")
+	out.WriteString("      <name>Waypoints</name>\n")
+	for _, point := range points {
+		var description []string
+		if point.Procedure != nil {
+			description = append(description, point.Procedure.Type.String()+" "+point.Procedure.Identifier)
+		}
+		if constraint := constraint(point); constraint > 0 {
+			description = append(description, "At "+number(float64(constraint), 0)+" ft")
+		}
+		out.WriteString("      <Placemark>\n")
+		out.WriteString("        <name>" + escape(point.Identifier) + "</name>\n")
+		if len(description) > 0 {
+			out.WriteString("        <description>" + escape(strings.Join(description, ", ")) + "</description>\n")
+		}
+		out.WriteString("        <Point>\n")
+		out.WriteString("          <coordinates>" + coordinate(point.Location.Longitude) + "," + coordinate(point.Location.Latitude) + "</coordinates>\n")
+		out.WriteString("        </Point>\n")
+		out.WriteString("      </Placemark>\n")
+	}
+	out.WriteString("    </Folder>\n")
+	out.WriteString("  </Document>\n")
+	out.WriteString("</kml>\n")
+	return out.Flush()
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// Internal function that returns the altitude constraint of a waypoint in feet, or zero if it has none
+func constraint(point golive.FlightPlanWaypoint) int {
+	if point.Altitude > 0 {
+		return point.Altitude
+	}
+	return 0
+}
+
+// Kinds of waypoints in simulator plans
+type waypointKind int
+
+const (
+	kindAirport waypointKind = iota
+	kindVor
+	kindFix
+	kindUser
+)
+
+// Internal function that guesses the kind of a waypoint from its identifier,
+// as the Live API does not tell them apart.
+// Airports are only recognised at the ends of the plan, and runways such as RW28R are user waypoints,
+// as simulators cannot resolve them as fixes.
+func kindOf(point golive.FlightPlanWaypoint, end bool) waypointKind {
+	identifier := point.Identifier
+	switch {
+	case isRunway(identifier):
+		return kindUser
+	case end && len(identifier) == 4 && isAlphanumeric(identifier) && identifier[0] >= 'A' && identifier[0] <= 'Z':
+		return kindAirport
+	case len(identifier) >= 2 && len(identifier) <= 3 && isAlphanumeric(identifier):
+		return kindVor
+	case len(identifier) == 5 && isAlphanumeric(identifier):
+		return kindFix
+	}
+	return kindUser
+}
+
+// Internal function that reports whether an identifier names a runway, such as RW28R or RW09
+func isRunway(identifier string) bool {
+	if !strings.HasPrefix(identifier, "RW") {
+		return false
+	}
+	number := identifier[2:]
+	if n := len(number); n > 0 && strings.ContainsRune("LCR", rune(number[n-1])) {
+		number = number[:n-1]
+	}
+	return len(number) >= 1 && len(number) <= 2 && strings.Trim(number, "0123456789") == ""
+}
+
+func isAlphanumeric(text string) bool {
+	return strings.Trim(text, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") == ""
+}
+
+// Internal function that returns the departure and destination airports of a plan, if it starts and ends at one
+func airports(points []golive.FlightPlanWaypoint) (departure *golive.FlightPlanWaypoint, destination *golive.FlightPlanWaypoint) {
+	if len(points) > 0 && kindOf(points[0], true) == kindAirport {
+		departure = &points[0]
+	}
+	if len(points) > 1 && kindOf(points[len(points)-1], true) == kindAirport {
+		destination = &points[len(points)-1]
+	}
+	return departure, destination
+}
+
+// Internal function that returns the AIRAC cycle in effect at a time, such as 2210.
+// Cycles last 28 days and are numbered from 01 within the year they take effect.
+func airacCycle(t time.Time) int {
+	// Cycle 2001 took effect on 2 January 2020
+	epoch := time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)
+	const period = 28 * 24 * time.Hour
+	elapsed := t.UTC().Sub(epoch)
+	cycles := int(elapsed / period)
+	if elapsed < 0 && elapsed%period != 0 {
+		cycles--
+	}
+	effective := epoch.Add(time.Duration(cycles) * period)
+	return effective.Year()%100*100 + (effective.YearDay()-1)/28 + 1
+}
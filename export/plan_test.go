@@ -0,0 +1,57 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/export"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func TestPlan(t *testing.T) {
+	plan := golivetest.DefaultFixtures().FlightPlans[golivetest.DeltaFlightId]
+	name := "Delta 123 KLAX-KSFO"
+
+	writers := []struct {
+		file  string
+		write func(io.Writer, golive.FlightPlan) error
+	}{
+		{"plan.geojson", func(w io.Writer, plan golive.FlightPlan) error { return export.WritePlanGeoJSON(w, name, plan) }},
+		{"plan.kml", func(w io.Writer, plan golive.FlightPlan) error { return export.WritePlanKML(w, name, plan) }},
+		{"plan.fms", export.WritePlanFMS},
+		{"plan.pln", func(w io.Writer, plan golive.FlightPlan) error { return export.WritePlanPLN(w, name, plan) }},
+	}
+	for _, writer := range writers {
+		var buffer bytes.Buffer
+		if err := writer.write(&buffer, plan); err != nil {
+			t.Fatalf("%s: %v", writer.file, err)
+		}
+		switch {
+		case strings.HasSuffix(writer.file, ".geojson"):
+			if !json.Valid(buffer.Bytes()) {
+				t.Errorf("%s: invalid JSON", writer.file)
+			}
+		case !strings.HasSuffix(writer.file, ".fms"):
+			wellFormed(t, buffer.Bytes())
+		}
+		// Every waypoint of the tree is exported once, in order
+		for _, identifier := range plan.Waypoints {
+			if !bytes.Contains(buffer.Bytes(), []byte(identifier)) {
+				t.Errorf("%s: missing %s", writer.file, identifier)
+			}
+		}
+		golden(t, writer.file, buffer.Bytes())
+
+		if err := writer.write(failingWriter{}, plan); err == nil {
+			t.Errorf("%s: expected the write error", writer.file)
+		}
+		buffer.Reset()
+		if err := writer.write(&buffer, golive.FlightPlan{}); err != nil {
+			t.Errorf("%s: empty plan: %v", writer.file, err)
+		}
+	}
+}
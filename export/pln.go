@@ -0,0 +1,109 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// WritePlanPLN writes a flight plan as an FSX and MSFS .pln document titled name.
+// Waypoints are placed at their altitude constraint in feet if they have one,
+// and those of a SID or STAR are tagged with its name as MSFS does.
+// Waypoint kinds are guessed from their identifiers like in WritePlanFMS.
+func WritePlanPLN(w io.Writer, name string, plan golive.FlightPlan) error {
+	points := plan.Flatten()
+	departure, destination := airports(points)
+	cruise := 0
+	for _, point := range points {
+		if constraint := constraint(point); constraint > cruise {
+			cruise = constraint
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	out.WriteString(xmlHeader)
+	out.WriteString(`<SimBase.Document Type="AceXML" version="1,0">` + "\n")
+	out.WriteString("  <Descr>AceXML Document</Descr>\n")
+	out.WriteString("  <FlightPlan.FlightPlan>\n")
+	out.WriteString("    <Title>" + escape(name) + "</Title>\n")
+	out.WriteString("    <FPType>IFR</FPType>\n")
+	out.WriteString("    <CruisingAlt>" + strconv.Itoa(cruise) + "</CruisingAlt>\n")
+	if departure != nil {
+		out.WriteString("    <DepartureID>" + escape(departure.Identifier) + "</DepartureID>\n")
+		out.WriteString("    <DepartureLLA>" + lla(departure.Location.Latitude, departure.Location.Longitude, departure.Location.Altitude) + "</DepartureLLA>\n")
+	}
+	if destination != nil {
+		out.WriteString("    <DestinationID>" + escape(destination.Identifier) + "</DestinationID>\n")
+		out.WriteString("    <DestinationLLA>" + lla(destination.Location.Latitude, destination.Location.Longitude, destination.Location.Altitude) + "</DestinationLLA>\n")
+	}
+	out.WriteString("    <Descr>" + escape(name) + "</Descr>\n")
+	if departure != nil {
+		out.WriteString("    <DepartureName>" + escape(departure.Name) + "</DepartureName>\n")
+	}
+	if destination != nil {
+		out.WriteString("    <DestinationName>" + escape(destination.Name) + "</DestinationName>\n")
+	}
+	out.WriteString("    <AppVersion>\n")
+	out.WriteString("      <AppVersionMajor>11</AppVersionMajor>\n")
+	out.WriteString("      <AppVersionBuild>282174</AppVersionBuild>\n")
+	out.WriteString("    </AppVersion>\n")
+
+	for i, point := range points {
+		kind := kindOf(point, i == 0 || i == len(points)-1)
+		altitude := float64(constraint(point))
+		if kind == kindAirport {
+			altitude = point.Location.Altitude
+		}
+		out.WriteString(`    <ATCWaypoint id="` + escape(point.Identifier) + `">` + "\n")
+		out.WriteString("      <ATCWaypointType>" + plnTypes[kind] + "</ATCWaypointType>\n")
+		out.WriteString("      <WorldPosition>" + lla(point.Location.Latitude, point.Location.Longitude, altitude) + "</WorldPosition>\n")
+		if point.Procedure != nil {
+			switch point.Procedure.Type {
+			case golive.FlightPlanItemSid:
+				out.WriteString("      <DepartureFP>" + escape(point.Procedure.Identifier) + "</DepartureFP>\n")
+			case golive.FlightPlanItemStar:
+				out.WriteString("      <ArrivalFP>" + escape(point.Procedure.Identifier) + "</ArrivalFP>\n")
+			}
+		}
+		if kind != kindUser {
+			out.WriteString("      <ICAO>\n")
+			out.WriteString("        <ICAOIdent>" + escape(point.Identifier) + "</ICAOIdent>\n")
+			out.WriteString("      </ICAO>\n")
+		}
+		out.WriteString("    </ATCWaypoint>\n")
+	}
+	out.WriteString("  </FlightPlan.FlightPlan>\n")
+	out.WriteString("</SimBase.Document>\n")
+	return out.Flush()
+}
+
+var plnTypes = map[waypointKind]string{
+	kindAirport: "Airport",
+	kindVor:     "VOR",
+	kindFix:     "Intersection",
+	kindUser:    "User",
+}
+
+// Internal function that formats a position as in .pln files, such as N33° 56' 33.00",W118° 24' 29.16",+000125.00
+func lla(latitude float64, longitude float64, altitude float64) string {
+	return dms(latitude, "N", "S") + "," + dms(longitude, "E", "W") + "," + fmt.Sprintf("%+010.2f", altitude)
+}
+
+// Internal function that formats an angle in degrees, minutes and seconds
+func dms(angle float64, positive string, negative string) string {
+	hemisphere := positive
+	if angle < 0 {
+		hemisphere = negative
+		angle = -angle
+	}
+	// Work in hundredths of a second so that rounding carries over
+	hundredths := int64(math.Round(angle * 360000))
+	degrees := hundredths / 360000
+	minutes := hundredths / 6000 % 60
+	seconds := float64(hundredths%6000) / 100
+	return fmt.Sprintf("%s%d° %d' %.2f\"", hemisphere, degrees, minutes, seconds)
+}
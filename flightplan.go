@@ -0,0 +1,135 @@
+package golive
+
+import "github.com/sqeezelemon/golive/geo"
+
+// FlightPlanWaypoint is a flight plan item with a location, as returned by FlightPlan.Flatten.
+type FlightPlanWaypoint struct {
+	FlightPlanItem
+	// Procedure is the SID, STAR, approach or track the waypoint belongs to, nil if it is enroute.
+	Procedure *FlightPlanItem
+}
+
+// FlightPlanLeg is the great-circle path between two consecutive waypoints.
+type FlightPlanLeg struct {
+	From FlightPlanWaypoint
+	To   FlightPlanWaypoint
+	// Distance in nautical miles
+	Distance float64
+	// Course is the initial true course from From to To.
+	Course float64
+}
+
+// Flatten returns the items of the flight plan that have a location in the order they are flown,
+// procedures included. Items without a location, such as the procedures themselves, are left out.
+func (fp FlightPlan) Flatten() []FlightPlanWaypoint {
+	return flattenItems(fp.FlightPlanItems, nil, nil)
+}
+
+// Internal function that appends the items with a location to result depth first,
+// with the innermost procedure each one belongs to
+func flattenItems(items []FlightPlanItem, procedure *FlightPlanItem, result []FlightPlanWaypoint) []FlightPlanWaypoint {
+	for _, item := range items {
+		if item.Location.Latitude != 0 || item.Location.Longitude != 0 {
+			result = append(result, FlightPlanWaypoint{item, procedure})
+		}
+		inner := procedure
+		if item.isProcedure() {
+			copied := item
+			inner = &copied
+		}
+		result = flattenItems(item.Children, inner, result)
+	}
+	return result
+}
+
+func (item FlightPlanItem) isProcedure() bool {
+	switch item.Type {
+	case FlightPlanItemSid, FlightPlanItemStar, FlightPlanItemApproach, FlightPlanItemTrack:
+		return true
+	}
+	return false
+}
+
+// Origin returns the first waypoint of the flight plan, usually the departure airport.
+// It returns false if the plan is empty or starts with a procedure.
+func (fp FlightPlan) Origin() (FlightPlanItem, bool) {
+	waypoints := fp.Flatten()
+	if len(waypoints) == 0 || waypoints[0].Procedure != nil {
+		return FlightPlanItem{}, false
+	}
+	return waypoints[0].FlightPlanItem, true
+}
+
+// Destination returns the last waypoint of the flight plan, usually the arrival airport.
+// It returns false if the plan has fewer than two waypoints or ends with a procedure.
+func (fp FlightPlan) Destination() (FlightPlanItem, bool) {
+	waypoints := fp.Flatten()
+	if len(waypoints) < 2 || waypoints[len(waypoints)-1].Procedure != nil {
+		return FlightPlanItem{}, false
+	}
+	return waypoints[len(waypoints)-1].FlightPlanItem, true
+}
+
+// Procedures returns the SIDs, STARs, approaches and tracks of the flight plan in order.
+func (fp FlightPlan) Procedures() []FlightPlanItem {
+	return procedureItems(fp.FlightPlanItems, nil)
+}
+
+// Internal function that appends the procedures among items to result, depth first
+func procedureItems(items []FlightPlanItem, result []FlightPlanItem) []FlightPlanItem {
+	for _, item := range items {
+		if item.isProcedure() {
+			result = append(result, item)
+		}
+		result = procedureItems(item.Children, result)
+	}
+	return result
+}
+
+// Procedure returns the first procedure of a type in the flight plan,
+// such as its SID with FlightPlanItemSid.
+func (fp FlightPlan) Procedure(t FlightPlanItemType) (FlightPlanItem, bool) {
+	for _, procedure := range fp.Procedures() {
+		if procedure.Type == t {
+			return procedure, true
+		}
+	}
+	return FlightPlanItem{}, false
+}
+
+// Enroute returns the waypoints outside of procedures, without the origin and destination.
+func (fp FlightPlan) Enroute() []FlightPlanWaypoint {
+	waypoints := fp.Flatten()
+	if _, ok := fp.Origin(); ok {
+		waypoints = waypoints[1:]
+	}
+	if _, ok := fp.Destination(); ok {
+		waypoints = waypoints[:len(waypoints)-1]
+	}
+	var enroute []FlightPlanWaypoint
+	for _, waypoint := range waypoints {
+		if waypoint.Procedure == nil {
+			enroute = append(enroute, waypoint)
+		}
+	}
+	return enroute
+}
+
+// Legs returns the legs between consecutive waypoints of the flight plan.
+func (fp FlightPlan) Legs() []FlightPlanLeg {
+	waypoints := fp.Flatten()
+	if len(waypoints) < 2 {
+		return nil
+	}
+	legs := make([]FlightPlanLeg, len(waypoints)-1)
+	for i := range legs {
+		from, to := waypoints[i], waypoints[i+1]
+		legs[i] = FlightPlanLeg{
+			From:     from,
+			To:       to,
+			Distance: geo.Distance(from.Location.Point(), to.Location.Point()),
+			Course:   geo.InitialBearing(from.Location.Point(), to.Location.Point()),
+		}
+	}
+	return legs
+}
@@ -0,0 +1,141 @@
+package golive_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+// Internal function that loads a flight plan as returned by the Live API from testdata
+func loadFlightPlan(t *testing.T, name string) golive.FlightPlan {
+	t.Helper()
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plan golive.FlightPlan
+	if err := json.Unmarshal(b, &plan); err != nil {
+		t.Fatal(err)
+	}
+	return plan
+}
+
+func itemIds(items []golive.FlightPlanItem) []string {
+	ids := []string{}
+	for _, item := range items {
+		ids = append(ids, item.Identifier)
+	}
+	return ids
+}
+
+func waypointIds(waypoints []golive.FlightPlanWaypoint) []string {
+	ids := []string{}
+	for _, waypoint := range waypoints {
+		ids = append(ids, waypoint.Identifier)
+	}
+	return ids
+}
+
+func TestFlightPlanTree(t *testing.T) {
+	tests := []struct {
+		name        string
+		plan        golive.FlightPlan
+		origin      string
+		destination string
+		procedures  []string
+		enroute     []string
+		distance    float64
+	}{
+		{
+			name:        "KLAX-KSFO",
+			plan:        golivetest.DefaultFixtures().FlightPlans[golivetest.DeltaFlightId],
+			origin:      "KLAX",
+			destination: "KSFO",
+			procedures:  []string{"DOTSS2", "SERFR3", "I28R"},
+			enroute:     []string{"RZS", "AVE"},
+			distance:    338,
+		},
+		{
+			name:        "EGLL-KJFK",
+			plan:        loadFlightPlan(t, "flightplan_egll_kjfk.json"),
+			origin:      "EGLL",
+			destination: "KJFK",
+			procedures:  []string{"CPT3F", "NATA", "PARCH3", "I04R"},
+			enroute:     []string{"STU", "PIKIL", "DOTTY"},
+			// The great circle is 2991 nm
+			distance: 3059,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := test.plan
+			waypoints := plan.Flatten()
+			if ids := waypointIds(waypoints); !reflect.DeepEqual(ids, plan.Waypoints) {
+				t.Errorf("flattened to %v, expected %v", ids, plan.Waypoints)
+			}
+			for _, waypoint := range waypoints {
+				if waypoint.Procedure != nil && waypoint.Procedure.Type == golive.FlightPlanItemUnknown {
+					t.Errorf("%s belongs to %s, which is not a procedure", waypoint.Identifier, waypoint.Procedure.Identifier)
+				}
+			}
+
+			if origin, ok := plan.Origin(); !ok || origin.Identifier != test.origin {
+				t.Errorf("expected origin %s, got %q", test.origin, origin.Identifier)
+			}
+			if destination, ok := plan.Destination(); !ok || destination.Identifier != test.destination {
+				t.Errorf("expected destination %s, got %q", test.destination, destination.Identifier)
+			}
+			if ids := itemIds(plan.Procedures()); !reflect.DeepEqual(ids, test.procedures) {
+				t.Errorf("expected procedures %v, got %v", test.procedures, ids)
+			}
+			if sid, ok := plan.Procedure(golive.FlightPlanItemSid); !ok || sid.Identifier != test.procedures[0] {
+				t.Errorf("unexpected SID %q", sid.Identifier)
+			}
+			if ids := waypointIds(plan.Enroute()); !reflect.DeepEqual(ids, test.enroute) {
+				t.Errorf("expected enroute waypoints %v, got %v", test.enroute, ids)
+			}
+
+			legs := plan.Legs()
+			if len(legs) != len(waypoints)-1 {
+				t.Fatalf("expected %d legs, got %d", len(waypoints)-1, len(legs))
+			}
+			var total float64
+			for i, leg := range legs {
+				if leg.From.Identifier != waypoints[i].Identifier || leg.To.Identifier != waypoints[i+1].Identifier {
+					t.Errorf("leg %d goes from %s to %s", i, leg.From.Identifier, leg.To.Identifier)
+				}
+				if leg.Course < 0 || leg.Course >= 360 {
+					t.Errorf("leg %d has course %.1f", i, leg.Course)
+				}
+				total += leg.Distance
+			}
+			if math.Abs(total-plan.TotalDistance()) > 1e-9 {
+				t.Errorf("legs add up to %.1f, TotalDistance is %.1f", total, plan.TotalDistance())
+			}
+			if math.Abs(total-test.distance)/test.distance > 0.03 {
+				t.Errorf("expected about %.0f nm, got %.1f", test.distance, total)
+			}
+		})
+	}
+
+	// Every leg along the oceanic track heads west
+	plan := loadFlightPlan(t, "flightplan_egll_kjfk.json")
+	for _, leg := range plan.Legs() {
+		if leg.From.Procedure != nil && leg.From.Procedure.Identifier == "NATA" && (leg.Course < 225 || leg.Course > 315) {
+			t.Errorf("%s to %s has course %.1f", leg.From.Identifier, leg.To.Identifier, leg.Course)
+		}
+	}
+
+	var empty golive.FlightPlan
+	if _, ok := empty.Origin(); ok {
+		t.Error("empty plan has an origin")
+	}
+	if legs := empty.Legs(); legs != nil {
+		t.Errorf("empty plan has legs %v", legs)
+	}
+}
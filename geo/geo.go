@@ -0,0 +1,144 @@
+// Package geo implements great-circle calculations on a spherical Earth.
+// Distances are in nautical miles and angles in degrees.
+package geo
+
+import "math"
+
+// EarthRadius is the mean radius of the Earth in nautical miles.
+const EarthRadius = 3440.065
+
+// Unit conversions
+const (
+	MetersPerNauticalMile = 1852.0
+	FeetPerNauticalMile   = MetersPerNauticalMile / 0.3048
+)
+
+// Point is a position on the Earth's surface.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// Internal function that returns the angular distance between two points in radians
+func angularDistance(a Point, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * math.Atan2(math.Sqrt(h), math.Sqrt(1-h))
+}
+
+// Distance returns the great-circle distance between two points.
+func Distance(a Point, b Point) float64 {
+	return angularDistance(a, b) * EarthRadius
+}
+
+// InitialBearing returns the true course at a towards b, between 0 and 360.
+func InitialBearing(a Point, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLon := radians(b.Longitude - a.Longitude)
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return NormalizeBearing(degrees(math.Atan2(y, x)))
+}
+
+// Destination returns the point reached by travelling distance from p along the initial bearing.
+func Destination(p Point, bearing float64, distance float64) Point {
+	lat1, lon1 := radians(p.Latitude), radians(p.Longitude)
+	angle := distance / EarthRadius
+	course := radians(bearing)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(angle) + math.Cos(lat1)*math.Sin(angle)*math.Cos(course))
+	lon2 := lon1 + math.Atan2(math.Sin(course)*math.Sin(angle)*math.Cos(lat1), math.Cos(angle)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{degrees(lat2), NormalizeLongitude(degrees(lon2))}
+}
+
+// CrossTrackDistance returns the distance from p to the great circle through start and end,
+// positive if p is to the right of the path and negative if to the left.
+func CrossTrackDistance(p Point, start Point, end Point) float64 {
+	d13 := angularDistance(start, p)
+	theta13 := radians(InitialBearing(start, p))
+	theta12 := radians(InitialBearing(start, end))
+	return math.Asin(math.Sin(d13)*math.Sin(theta13-theta12)) * EarthRadius
+}
+
+// AlongTrackDistance returns the distance from start to the point on the great circle
+// through start and end closest to p, negative if that point lies behind start.
+func AlongTrackDistance(p Point, start Point, end Point) float64 {
+	d13 := angularDistance(start, p)
+	theta13 := radians(InitialBearing(start, p))
+	theta12 := radians(InitialBearing(start, end))
+	dxt := math.Asin(math.Sin(d13) * math.Sin(theta13-theta12))
+	along := math.Acos(math.Max(-1, math.Min(1, math.Cos(d13)/math.Cos(dxt))))
+	if math.Cos(theta13-theta12) < 0 {
+		along = -along
+	}
+	return along * EarthRadius
+}
+
+// NormalizeBearing maps any angle to [0, 360).
+func NormalizeBearing(bearing float64) float64 {
+	bearing = math.Mod(bearing, 360)
+	if bearing < 0 {
+		bearing += 360
+	}
+	return bearing
+}
+
+// NormalizeLongitude maps any longitude to [-180, 180).
+func NormalizeLongitude(longitude float64) float64 {
+	return NormalizeBearing(longitude+180) - 180
+}
+
+// BoundingBox is an area between two latitudes and two longitudes.
+// If West is greater than East the box crosses the antimeridian.
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// Contains reports whether p lies inside the box, edges included.
+func (b BoundingBox) Contains(p Point) bool {
+	if p.Latitude < b.South || p.Latitude > b.North {
+		return false
+	}
+	longitude := NormalizeLongitude(p.Longitude)
+	if b.West <= b.East {
+		return longitude >= b.West && longitude <= b.East
+	}
+	return longitude >= b.West || longitude <= b.East
+}
+
+// BoundingBoxAround returns a box containing every point within radius of center.
+func BoundingBoxAround(center Point, radius float64) BoundingBox {
+	angle := degrees(radius / EarthRadius)
+	box := BoundingBox{
+		South: center.Latitude - angle,
+		North: center.Latitude + angle,
+	}
+	if box.South <= -90 || box.North >= 90 {
+		// A pole is inside the circle, so every longitude is
+		box.South = math.Max(box.South, -90)
+		box.North = math.Min(box.North, 90)
+		box.West, box.East = -180, 180
+		return box
+	}
+
+	// Longitude span at the latitude where the circle is widest
+	spread := degrees(math.Asin(math.Sin(radians(angle)) / math.Cos(radians(center.Latitude))))
+	box.West = NormalizeLongitude(center.Longitude - spread)
+	box.East = NormalizeLongitude(center.Longitude + spread)
+	if box.East == -180 {
+		box.East = 180
+	}
+	return box
+}
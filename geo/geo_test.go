@@ -0,0 +1,118 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	klax = Point{33.9425, -118.4081}
+	kjfk = Point{40.6398, -73.7789}
+)
+
+func near(a float64, b float64, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestDistance(t *testing.T) {
+	if d := Distance(klax, kjfk); !near(d, 2146, 2) {
+		t.Errorf("KLAX-KJFK: expected about 2146 nm, got %.1f", d)
+	}
+	if d := Distance(Point{0, 0}, Point{1, 0}); !near(d, 60.04, 0.01) {
+		t.Errorf("one degree of latitude: expected 60.04 nm, got %.3f", d)
+	}
+	if d := Distance(Point{0, 179.5}, Point{0, -179.5}); !near(d, 60.04, 0.01) {
+		t.Errorf("across the antimeridian: expected 60.04 nm, got %.3f", d)
+	}
+	if d := Distance(klax, klax); d != 0 {
+		t.Errorf("expected zero distance, got %v", d)
+	}
+}
+
+func TestBearing(t *testing.T) {
+	if b := InitialBearing(klax, kjfk); !near(b, 65.9, 0.2) {
+		t.Errorf("KLAX-KJFK: expected about 65.9, got %.2f", b)
+	}
+	if b := InitialBearing(Point{0, 0}, Point{0, -1}); !near(b, 270, 1e-9) {
+		t.Errorf("expected due west, got %v", b)
+	}
+}
+
+func TestDestination(t *testing.T) {
+	p := Destination(klax, InitialBearing(klax, kjfk), Distance(klax, kjfk))
+	if !near(p.Latitude, kjfk.Latitude, 1e-6) || !near(p.Longitude, kjfk.Longitude, 1e-6) {
+		t.Errorf("expected to reach KJFK, got %+v", p)
+	}
+	p = Destination(Point{0, 179.9}, 90, 60.04)
+	if !near(p.Longitude, -179.1, 0.01) {
+		t.Errorf("expected longitude to wrap, got %+v", p)
+	}
+}
+
+func TestTrackDistances(t *testing.T) {
+	start, end := Point{0, 0}, Point{0, 10}
+	if d := CrossTrackDistance(Point{1, 5}, start, end); !near(d, -60.04, 0.01) {
+		t.Errorf("expected point 60 nm left of an eastbound track, got %.3f", d)
+	}
+	if d := CrossTrackDistance(Point{-1, 5}, start, end); !near(d, 60.04, 0.01) {
+		t.Errorf("expected point 60 nm right of an eastbound track, got %.3f", d)
+	}
+	if d := AlongTrackDistance(Point{1, 5}, start, end); !near(d, 300.2, 0.2) {
+		t.Errorf("expected about 300 nm along track, got %.3f", d)
+	}
+	if d := AlongTrackDistance(Point{0, -1}, start, end); !near(d, -60.04, 0.01) {
+		t.Errorf("expected point behind start, got %.3f", d)
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	box := BoundingBoxAround(klax, 50)
+	for _, bearing := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+		if p := Destination(klax, bearing, 49.9); !box.Contains(p) {
+			t.Errorf("expected %+v at %v degrees to be inside %+v", p, bearing, box)
+		}
+	}
+	if box.Contains(kjfk) {
+		t.Error("expected KJFK outside the box")
+	}
+
+	pacific := BoundingBoxAround(Point{0, 179.5}, 120)
+	if pacific.West < pacific.East {
+		t.Fatalf("expected box to cross the antimeridian, got %+v", pacific)
+	}
+	if !pacific.Contains(Point{0, -179.5}) || pacific.Contains(Point{0, 0}) {
+		t.Errorf("unexpected containment for %+v", pacific)
+	}
+
+	polar := BoundingBoxAround(Point{89, 0}, 120)
+	if !polar.Contains(Point{89.5, 120}) {
+		t.Errorf("expected every longitude near the pole inside %+v", polar)
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	// Roughly the Los Angeles basin
+	basin := Polygon{{34.3, -118.9}, {34.3, -117.6}, {33.6, -117.6}, {33.6, -118.9}}
+	if !basin.Contains(klax) {
+		t.Error("expected KLAX inside")
+	}
+	if basin.Contains(kjfk) || basin.Contains(Point{34.0, -119.0}) {
+		t.Error("expected points outside")
+	}
+	box := basin.BoundingBox()
+	if box != (BoundingBox{South: 33.6, West: -118.9, North: 34.3, East: -117.6}) {
+		t.Errorf("unexpected bounding box %+v", box)
+	}
+
+	// A concave polygon crossing the antimeridian
+	pacific := Polygon{{-10, 170}, {-10, -170}, {10, -170}, {10, 170}, {0, 175}}
+	if !pacific.Contains(Point{5, 179}) || !pacific.Contains(Point{-5, -175}) {
+		t.Error("expected points inside across the antimeridian")
+	}
+	if pacific.Contains(Point{0, 172}) || pacific.Contains(Point{0, 0}) {
+		t.Error("expected points outside")
+	}
+	if box := pacific.BoundingBox(); box.West != 170 || box.East != -170 {
+		t.Errorf("unexpected bounding box %+v", box)
+	}
+}
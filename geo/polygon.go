@@ -0,0 +1,52 @@
+package geo
+
+import "math"
+
+// Polygon is an area bounded by straight lines between its vertices in latitude and longitude,
+// which is a good approximation for areas up to a few hundred miles across.
+// The last vertex connects back to the first. Polygons may cross the antimeridian
+// but must span less than 180 degrees of longitude.
+type Polygon []Point
+
+// Contains reports whether p lies inside the polygon.
+func (polygon Polygon) Contains(p Point) bool {
+	if len(polygon) == 0 {
+		return false
+	}
+	// Longitudes relative to the first vertex, so the antimeridian never gets in the way
+	origin := polygon[0].Longitude
+	px := NormalizeLongitude(p.Longitude - origin)
+	inside := false
+	for i := range polygon {
+		a, b := polygon[i], polygon[(i+1)%len(polygon)]
+		ax := NormalizeLongitude(a.Longitude - origin)
+		bx := NormalizeLongitude(b.Longitude - origin)
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) {
+			// Cast a ray from p towards positive longitudes
+			x := ax + (p.Latitude-a.Latitude)*(bx-ax)/(b.Latitude-a.Latitude)
+			if x > px {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// BoundingBox returns the smallest box containing the polygon.
+func (polygon Polygon) BoundingBox() BoundingBox {
+	if len(polygon) == 0 {
+		return BoundingBox{}
+	}
+	origin := polygon[0].Longitude
+	box := BoundingBox{South: 90, North: -90, West: math.Inf(1), East: math.Inf(-1)}
+	for _, vertex := range polygon {
+		box.South = math.Min(box.South, vertex.Latitude)
+		box.North = math.Max(box.North, vertex.Latitude)
+		offset := NormalizeLongitude(vertex.Longitude - origin)
+		box.West = math.Min(box.West, offset)
+		box.East = math.Max(box.East, offset)
+	}
+	box.West = NormalizeLongitude(origin + box.West)
+	box.East = NormalizeLongitude(origin + box.East)
+	return box
+}
@@ -0,0 +1,201 @@
+package golivetest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Headers removed from recorded requests and responses
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Interaction is one recorded HTTP exchange, stored as a line of a JSONL cassette.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Cassette is an http.RoundTripper that either records real exchanges to a JSONL file
+// or replays them from one. Use it as the Transport of the http.Client given to golive.NewClient.
+type Cassette struct {
+	mutex     sync.Mutex
+	recording bool
+	transport http.RoundTripper
+	file      *os.File
+	// Replayed interactions and whether each was used already
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder creates a cassette that sends requests through transport
+// (http.DefaultTransport if nil) and writes every exchange to path, replacing its contents.
+// Authorization, Cookie and Set-Cookie headers are not recorded.
+// The responses returned to the client keep their headers.
+func NewRecorder(path string, transport http.RoundTripper) (*Cassette, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Cassette{recording: true, transport: transport, file: file}, nil
+}
+
+// NewReplayer creates a cassette that answers requests from the exchanges recorded in path
+// without touching the network. Requests are matched on method, path, query and body,
+// identical requests get the recorded responses in order.
+func NewReplayer(path string) (*Cassette, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	c := &Cassette{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("golivetest: %s:%d: %w", path, line, err)
+		}
+		c.interactions = append(c.interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	c.used = make([]bool, len(c.interactions))
+	return c, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.recording {
+		return c.record(r, body)
+	}
+	return c.replay(r, body)
+}
+
+// Close closes the file of a recording cassette.
+func (c *Cassette) Close() error {
+	if c.file == nil {
+		return nil
+	}
+	return c.file.Close()
+}
+
+func (c *Cassette) record(r *http.Request, body []byte) (*http.Response, error) {
+	outgoing := r.Clone(r.Context())
+	outgoing.Body = io.NopCloser(bytes.NewReader(body))
+	outgoing.ContentLength = int64(len(body))
+	response, err := c.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	line, err := json.Marshal(Interaction{
+		Request: RecordedRequest{
+			Method: r.Method,
+			URL:    r.URL.String(),
+			Header: scrub(r.Header),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     scrub(response.Header),
+			Body:       string(responseBody),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Internal function that returns a copy of header without the scrubbed headers
+func scrub(header http.Header) http.Header {
+	header = header.Clone()
+	for _, key := range scrubbedHeaders {
+		header.Del(key)
+	}
+	return header
+}
+
+func (c *Cassette) replay(r *http.Request, body []byte) (*http.Response, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.used[i] || !matches(interaction.Request, r, body) {
+			continue
+		}
+		c.used[i] = true
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       r,
+		}, nil
+	}
+	return nil, fmt.Errorf("golivetest: no unused recorded interaction for %s %s", r.Method, r.URL.RequestURI())
+}
+
+// Internal function that reports whether a recorded request matches r, ignoring the host
+func matches(recorded RecordedRequest, r *http.Request, body []byte) bool {
+	if recorded.Method != r.Method || recorded.Body != string(body) {
+		return false
+	}
+	recordedURL, err := url.Parse(recorded.URL)
+	return err == nil && recordedURL.RequestURI() == r.URL.RequestURI()
+}
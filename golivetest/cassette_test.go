@@ -0,0 +1,123 @@
+package golivetest
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+)
+
+func TestCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	server := NewServer(nil)
+	server.APIKey = "secret"
+	recorder, err := NewRecorder(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := golive.NewClient("secret", &http.Client{Transport: recorder}, golive.WithBaseURL(server.URL+BasePath))
+	flights, err := live.GetFlights(ExpertSessionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := live.GetUserStats([]string{KaiUserId}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := live.GetAtis(ExpertSessionId, "KSFO"); err == nil {
+		t.Fatal("expected ErrNoAtis")
+	}
+	recorder.Close()
+	server.Close()
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(cassette), "secret") {
+		t.Error("cassette contains the API key")
+	}
+	if lines := strings.Count(string(cassette), "\n"); lines != 3 {
+		t.Errorf("expected 3 interactions, got %d", lines)
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := golive.NewClient("", &http.Client{Transport: replayer}, golive.WithBaseURL("http://golive.invalid/public/v2"))
+	replayedFlights, err := offline.GetFlights(ExpertSessionId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(flights, replayedFlights) {
+		t.Errorf("replayed flights differ:\n%+v\n%+v", flights, replayedFlights)
+	}
+	replayedStats, err := offline.GetUserStats([]string{KaiUserId}, nil, nil)
+	if err != nil || !reflect.DeepEqual(stats, replayedStats) {
+		t.Errorf("replayed stats differ: %v", err)
+	}
+	if _, err := offline.GetAtis(ExpertSessionId, "KSFO"); err == nil {
+		t.Error("expected replayed ErrNoAtis")
+	}
+
+	// Every interaction is used once
+	if _, err := offline.GetFlights(ExpertSessionId); err == nil {
+		t.Error("expected error for exhausted interaction")
+	}
+	if _, err := offline.GetUserStats([]string{LauraUserId}, nil, nil); err == nil {
+		t.Error("expected error for request with a different body")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestCassetteScrubsResponses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Content-Type", "application/json")
+		header.Set("Set-Cookie", "session=secret; HttpOnly")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{"errorCode":0,"result":[]}`)),
+			Request:    r,
+		}, nil
+	})
+	recorder, err := NewRecorder(path, transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, _ := http.NewRequest(http.MethodGet, "http://golive.invalid/public/v2/sessions", nil)
+	request.Header.Set("Cookie", "session=secret")
+	response, err := recorder.RoundTrip(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.Close()
+	if response.Header.Get("Set-Cookie") == "" {
+		t.Error("expected the live response to keep its cookie")
+	}
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(cassette), "secret") {
+		t.Errorf("cassette contains session material: %s", cassette)
+	}
+	if !strings.Contains(string(cassette), "application/json") {
+		t.Errorf("expected other headers to be recorded: %s", cassette)
+	}
+}
@@ -0,0 +1,343 @@
+package golivetest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// DefaultPageSize is the logbook page size used when Fixtures.PageSize is zero.
+const DefaultPageSize = 10
+
+// Identifiers used by DefaultFixtures
+const (
+	CasualSessionId   = "d01006e4-3114-473c-8f69-020b89d02884"
+	TrainingSessionId = "6a04ffe8-765a-4925-af26-d88029eeadba"
+	ExpertSessionId   = "7e5dcd44-1fb5-49cc-bc2c-a9aab1f6a856"
+
+	KaiUserId   = "2a11e620-1cc1-4ac6-90d1-18c4ed9cb913"
+	LauraUserId = "5917d076-88a5-40e7-95e0-8818748f8e99"
+
+	A320Id   = "4f6b9a2c-3f3e-4b3b-9d11-0a4a4c0e2a01"
+	B77WId   = "0b6b7a8e-8c9e-4f49-8a7c-6f6d1c2e3b02"
+	B738Id   = "5d3f1c0e-2b7a-4c4d-9e8f-1a2b3c4d5e03"
+	C172Id   = "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a04"
+	DeltaId  = "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c11"
+	BritId   = "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e12"
+	SouthId  = "2d3e4f5a-6b7c-4d8e-9f0a-1b2c3d4e5f13"
+	CessnaId = "3e4f5a6b-7c8d-4e9f-0a1b-2c3d4e5f6a14"
+
+	DeltaFlightId     = "f7a4c1e2-0d3b-4c5a-8e9f-111111111111"
+	BritishFlightId   = "f7a4c1e2-0d3b-4c5a-8e9f-222222222222"
+	SouthwestFlightId = "f7a4c1e2-0d3b-4c5a-8e9f-333333333333"
+)
+
+// Fixtures is the data served by a Server.
+// Maps are keyed by session id, flight id or user id as noted.
+type Fixtures struct {
+	Sessions []golive.Session
+	// Flights by session id
+	Flights map[string][]golive.Flight
+	// Routes by flight id
+	Routes map[string][]golive.PositionReport
+	// FlightPlans by flight id
+	FlightPlans map[string]golive.FlightPlan
+	// Atc by session id
+	Atc map[string][]golive.ActiveAtcFacility
+	// Atis by session id, then airport ICAO
+	Atis map[string]map[string]string
+	// World by session id
+	World map[string][]golive.AirportStatus
+	// Notams by session id
+	Notams map[string][]golive.Notam
+	Users  []golive.UserStats
+	// Grades by user id
+	Grades map[string]golive.UserGrade
+	// UserFlights by user id, newest first
+	UserFlights map[string][]golive.LoggedFlight
+	// UserAtcSessions by user id, newest first
+	UserAtcSessions map[string][]golive.LoggedAtcSession
+	Tracks          []golive.Track
+	Aircraft        []golive.Aircraft
+	Liveries        []golive.Livery
+	// PageSize is the number of logbook entries per page, DefaultPageSize if zero.
+	PageSize int
+}
+
+func (f *Fixtures) session(id string) (golive.Session, bool) {
+	for _, session := range f.Sessions {
+		if session.Id == id {
+			return session, true
+		}
+	}
+	return golive.Session{}, false
+}
+
+func (f *Fixtures) flight(sessionId string, flightId string) (golive.Flight, bool) {
+	for _, flight := range f.Flights[sessionId] {
+		if flight.Id == flightId {
+			return flight, true
+		}
+	}
+	return golive.Flight{}, false
+}
+
+func (f *Fixtures) user(match func(golive.UserStats) bool) (golive.UserStats, bool) {
+	for _, user := range f.Users {
+		if match(user) {
+			return user, true
+		}
+	}
+	return golive.UserStats{}, false
+}
+
+func (f *Fixtures) airportStatus(sessionId string, icao string) golive.AirportStatus {
+	for _, status := range f.World[sessionId] {
+		if strings.EqualFold(status.AirportIcao, icao) {
+			return status
+		}
+	}
+	return golive.AirportStatus{
+		AirportIcao:     icao,
+		InboundFlights:  []string{},
+		OutboundFlights: []string{},
+		AtcFacilities:   []golive.ActiveAtcFacility{},
+	}
+}
+
+// Reference time of DefaultFixtures
+var fixtureTime = time.Date(2022, 10, 1, 18, 0, 0, 0, time.UTC)
+
+// DefaultFixtures returns a small but complete Expert Server:
+// a departure and an arrival at KLAX and an aircraft taxiing there,
+// KLAX and KSFO controlled, two users with logbooks, and the aircraft and liveries involved.
+// Every call returns a fresh copy that may be modified freely.
+func DefaultFixtures() *Fixtures {
+	at := func(minutes float64) golive.Time {
+		return golive.Time(fixtureTime.Add(time.Duration(minutes * float64(time.Minute))))
+	}
+
+	flights := []golive.Flight{
+		{
+			Username: "KaiM", Callsign: "Delta 123", UserId: KaiUserId, Id: DeltaFlightId,
+			Latitude: 34.0952, Longitude: -118.9517, Altitude: 12450, Speed: 287, VerticalSpeed: 2150,
+			Track: 292, Heading: 290, LastReport: at(0), AircraftId: A320Id, LiveryId: DeltaId,
+			VirtualOrganization: "Delta Virtual",
+		},
+		{
+			Username: "Laura", Callsign: "Speedbird 283", UserId: LauraUserId, Id: BritishFlightId,
+			Latitude: 33.9643, Longitude: -118.1120, Altitude: 2180, Speed: 152, VerticalSpeed: -760,
+			Track: 249, Heading: 250, LastReport: at(0), AircraftId: B77WId, LiveryId: BritId,
+		},
+		{
+			Callsign: "Southwest 1402", Id: SouthwestFlightId, UserId: "8c1b0e3c-61f5-4f70-9f0e-1d2a3b4c5d6e",
+			Latitude: 33.9448, Longitude: -118.4012, Altitude: 118, Speed: 14, Track: 82, Heading: 82,
+			LastReport: at(0), AircraftId: B738Id, LiveryId: SouthId,
+		},
+	}
+
+	atc := []golive.ActiveAtcFacility{
+		{
+			FrequencyId: "a1b2c3d4-0000-4000-8000-000000000001", UserId: LauraUserId, Username: "Laura",
+			AirportName: "KLAX", Type: golive.FacilityTower, Latitude: 33.9425, Longitude: -118.4081, StartTime: at(-95),
+		},
+		{
+			FrequencyId: "a1b2c3d4-0000-4000-8000-000000000002", UserId: LauraUserId, Username: "Laura",
+			AirportName: "KLAX", Type: golive.FacilityGround, Latitude: 33.9425, Longitude: -118.4081, StartTime: at(-60),
+		},
+		{
+			FrequencyId: "a1b2c3d4-0000-4000-8000-000000000003", UserId: "6e0f2a1b-7c3d-4e5f-8a9b-0c1d2e3f4a5b",
+			Username: "Tyler", AirportName: "KSFO", Type: golive.FacilityApproach, Latitude: 37.6189, Longitude: -122.3750,
+			StartTime: at(-40),
+		},
+	}
+
+	return &Fixtures{
+		Sessions: []golive.Session{
+			{Id: ExpertSessionId, Name: "Expert Server", MaxUsers: 1000, UserCount: 3, Type: golive.SessionRestricted},
+			{Id: TrainingSessionId, Name: "Training Server", MaxUsers: 1000, UserCount: 0, Type: golive.SessionUnrestricted},
+			{Id: CasualSessionId, Name: "Casual Server", MaxUsers: 1000, UserCount: 0, Type: golive.SessionUnrestricted},
+		},
+		Flights: map[string][]golive.Flight{
+			ExpertSessionId: flights,
+		},
+		Routes: map[string][]golive.PositionReport{
+			DeltaFlightId: {
+				{Latitude: 33.9497, Longitude: -118.4020, Altitude: 125, Track: 250, GroundSpeed: 0, Date: golive.Time(fixtureTime.Add(-9 * time.Minute))},
+				{Latitude: 33.9470, Longitude: -118.4020, Altitude: 125, Track: 250, GroundSpeed: 18, Date: golive.Time(fixtureTime.Add(-7 * time.Minute))},
+				{Latitude: 33.9462, Longitude: -118.4197, Altitude: 126, Track: 250, GroundSpeed: 152, Date: golive.Time(fixtureTime.Add(-5 * time.Minute))},
+				{Latitude: 33.9314, Longitude: -118.4859, Altitude: 1850, Track: 250, GroundSpeed: 176, Date: golive.Time(fixtureTime.Add(-4 * time.Minute))},
+				{Latitude: 33.9106, Longitude: -118.5912, Altitude: 4900, Track: 250, GroundSpeed: 221, Date: golive.Time(fixtureTime.Add(-3 * time.Minute))},
+				{Latitude: 33.9538, Longitude: -118.7394, Altitude: 7800, Track: 292, GroundSpeed: 255, Date: golive.Time(fixtureTime.Add(-2 * time.Minute))},
+				{Latitude: 34.0247, Longitude: -118.8462, Altitude: 10300, Track: 292, GroundSpeed: 271, Date: golive.Time(fixtureTime.Add(-1 * time.Minute))},
+				{Latitude: 34.0952, Longitude: -118.9517, Altitude: 12450, Track: 292, GroundSpeed: 287, Date: golive.Time(fixtureTime)},
+			},
+		},
+		FlightPlans: map[string]golive.FlightPlan{
+			DeltaFlightId: {
+				Id:         "c4d5e6f7-1111-4222-8333-444455556666",
+				FlightId:   DeltaFlightId,
+				Waypoints:  []string{"KLAX", "DOTSS", "RZS", "AVE", "SERFR", "EPICK", "AXMUL", "RW28R", "KSFO"},
+				LastUpdate: at(-12),
+				FlightPlanItems: []golive.FlightPlanItem{
+					{Name: "KLAX", Identifier: "KLAX", Type: golive.FlightPlanItemUnknown, Altitude: -1, Location: golive.Location{Latitude: 33.9425, Longitude: -118.4081, Altitude: 125}},
+					{Name: "DOTSS2", Identifier: "DOTSS2", Type: golive.FlightPlanItemSid, Altitude: -1, Children: []golive.FlightPlanItem{
+						{Name: "DOTSS", Identifier: "DOTSS", Type: golive.FlightPlanItemUnknown, Altitude: 8000, Location: golive.Location{Latitude: 33.8085, Longitude: -118.9496}},
+					}},
+					{Name: "RZS", Identifier: "RZS", Type: golive.FlightPlanItemUnknown, Altitude: -1, Location: golive.Location{Latitude: 34.5096, Longitude: -119.7707}},
+					{Name: "AVE", Identifier: "AVE", Type: golive.FlightPlanItemUnknown, Altitude: -1, Location: golive.Location{Latitude: 35.6469, Longitude: -119.9789}},
+					{Name: "SERFR3", Identifier: "SERFR3", Type: golive.FlightPlanItemStar, Altitude: -1, Children: []golive.FlightPlanItem{
+						{Name: "SERFR", Identifier: "SERFR", Type: golive.FlightPlanItemUnknown, Altitude: 11000, Location: golive.Location{Latitude: 36.0686, Longitude: -121.3648}},
+						{Name: "EPICK", Identifier: "EPICK", Type: golive.FlightPlanItemUnknown, Altitude: 10000, Location: golive.Location{Latitude: 36.9508, Longitude: -121.9527}},
+					}},
+					{Name: "I28R", Identifier: "I28R", Type: golive.FlightPlanItemApproach, Altitude: -1, Children: []golive.FlightPlanItem{
+						{Name: "AXMUL", Identifier: "AXMUL", Type: golive.FlightPlanItemUnknown, Altitude: 3000, Location: golive.Location{Latitude: 37.5932, Longitude: -122.1766}},
+						{Name: "RW28R", Identifier: "RW28R", Type: golive.FlightPlanItemUnknown, Altitude: 18, Location: golive.Location{Latitude: 37.6133, Longitude: -122.3571}},
+					}},
+					{Name: "KSFO", Identifier: "KSFO", Type: golive.FlightPlanItemUnknown, Altitude: -1, Location: golive.Location{Latitude: 37.6189, Longitude: -122.3750, Altitude: 13}},
+				},
+			},
+		},
+		Atc: map[string][]golive.ActiveAtcFacility{
+			ExpertSessionId: atc,
+		},
+		Atis: map[string]map[string]string{
+			ExpertSessionId: {
+				"KLAX": "Los Angeles International information Alpha. Wind 250 at 8. Landing and departing runways 25L, 25R.",
+			},
+		},
+		World: map[string][]golive.AirportStatus{
+			ExpertSessionId: {
+				{
+					AirportIcao:          "KLAX",
+					InboundFlightsCount:  1,
+					InboundFlights:       []string{BritishFlightId},
+					OutboundFlightsCount: 2,
+					OutboundFlights:      []string{DeltaFlightId, SouthwestFlightId},
+					AtcFacilities:        atc[:2],
+				},
+				{
+					AirportIcao:          "KSFO",
+					InboundFlightsCount:  1,
+					InboundFlights:       []string{DeltaFlightId},
+					OutboundFlightsCount: 0,
+					OutboundFlights:      []string{},
+					AtcFacilities:        atc[2:],
+				},
+			},
+		},
+		Notams: map[string][]golive.Notam{
+			ExpertSessionId: {
+				{
+					Id: "0e1f2a3b-4c5d-4e6f-8a7b-9c0d1e2f3a4b", Title: "KLAX Runway 25L closed", Author: "Laura",
+					SessionId: ExpertSessionId, Radius: 5, Message: "Use runway 25R for departures.",
+					Latitude: 33.9425, Longitude: -118.4081, Icao: "KLAX", Floor: 0, Ceiling: 3000,
+					StartTime: at(-120), EndTime: at(240),
+				},
+			},
+		},
+		Users: []golive.UserStats{
+			{
+				UserId: KaiUserId, DiscourseUsername: "KaiM", Hash: "F0081CAA", OnlineFlights: 1280, Xp: 912345,
+				LandingCount: 1504, FlightTime: 171240, AtcOperations: 360, Grade: 5, Roles: []golive.Role{golive.RoleStaff, golive.RoleModerator},
+				ViolationCountByLevel: golive.ViolationCount{Level1: 3},
+			},
+			{
+				UserId: LauraUserId, DiscourseUsername: "Laura", Hash: "E2087C9F", OnlineFlights: 845, Xp: 532100,
+				LandingCount: 990, FlightTime: 98650, AtcOperations: 40211, AtcRank: 4, Grade: 4, Roles: []golive.Role{golive.RoleStaff, golive.RoleIfatc},
+			},
+		},
+		Grades: map[string]golive.UserGrade{
+			KaiUserId: {
+				UserId: KaiUserId, DiscourseUsername: "KaiM", TotalXP: 912345, Roles: []golive.Role{golive.RoleStaff, golive.RoleModerator},
+				Total12MonthsViolations: 0, ViolationCountByLevel: golive.ViolationCount{Level1: 3},
+				GradeDetails: golive.GradeConfiguration{
+					GradeIndex: 4,
+					Grades: []golive.Grade{
+						{Index: 4, Name: "Grade 5", State: 1, Rules: []golive.GradeRule{
+							{RuleIndex: 0, ReferenceValue: 1200, UserValue: 1504, State: 1, UserValueString: "1504", ReferenceValueString: "1200"},
+						}},
+					},
+					RuleDefinitions: []golive.GradeRuleDefinition{
+						{Name: "Landings", Description: "Total landings", Property: "LandingCount", Operator: 3, Order: 0},
+					},
+				},
+				LastLevel1ViolationDate: golive.Time(fixtureTime.AddDate(-2, 0, 0))},
+			LauraUserId: {
+				UserId: LauraUserId, DiscourseUsername: "Laura", TotalXP: 532100, AtcOperations: 40211, AtcRank: 4,
+				Roles: []golive.Role{golive.RoleStaff, golive.RoleIfatc}, Groups: []string{"IFATC"},
+			},
+		},
+		UserFlights: map[string][]golive.LoggedFlight{
+			KaiUserId: loggedFlights(KaiUserId, 23),
+		},
+		UserAtcSessions: map[string][]golive.LoggedAtcSession{
+			KaiUserId:   loggedAtcSessions(3),
+			LauraUserId: loggedAtcSessions(12),
+		},
+		Tracks: []golive.Track{
+			{Name: "A", Path: []string{"ERAKA", "60N020W", "61N030W", "61N040W", "60N050W", "PELTU"}, EastLevels: []int{}, WestLevels: []int{350, 360, 370}, Type: "NAT", LastSeen: golive.Time(fixtureTime)},
+		},
+		Aircraft: []golive.Aircraft{
+			{Id: A320Id, Name: "Airbus A320"},
+			{Id: B77WId, Name: "Boeing 777-300ER"},
+			{Id: B738Id, Name: "Boeing 737-800"},
+			{Id: C172Id, Name: "Cessna 172"},
+		},
+		Liveries: []golive.Livery{
+			{Id: DeltaId, AircraftID: A320Id, AircraftName: "Airbus A320", LiveryName: "Delta"},
+			{Id: "4f5a6b7c-8d9e-4f0a-1b2c-3d4e5f6a7b15", AircraftID: A320Id, AircraftName: "Airbus A320", LiveryName: "Generic"},
+			{Id: BritId, AircraftID: B77WId, AircraftName: "Boeing 777-300ER", LiveryName: "British Airways"},
+			{Id: "5a6b7c8d-9e0f-4a1b-2c3d-4e5f6a7b8c16", AircraftID: B77WId, AircraftName: "Boeing 777-300ER", LiveryName: "Emirates"},
+			{Id: SouthId, AircraftID: B738Id, AircraftName: "Boeing 737-800", LiveryName: "Southwest Airlines"},
+			{Id: CessnaId, AircraftID: C172Id, AircraftName: "Cessna 172", LiveryName: "Generic"},
+		},
+	}
+}
+
+// Internal function that generates n logbook flights, one a day going back from fixtureTime
+func loggedFlights(userId string, n int) []golive.LoggedFlight {
+	airports := []string{"KLAX", "KSFO", "KSEA", "KDEN"}
+	flights := make([]golive.LoggedFlight, n)
+	for i := range flights {
+		flights[i] = golive.LoggedFlight{
+			Id:                 fmt.Sprintf("b0000000-0000-4000-8000-%012d", i+1),
+			Created:            golive.Time(fixtureTime.AddDate(0, 0, -i)),
+			UserId:             userId,
+			AircraftId:         A320Id,
+			LiveryId:           DeltaId,
+			Callsign:           "Delta 123",
+			Server:             "Expert",
+			DayTime:            55.5,
+			TotalTime:          55.5,
+			LandingCount:       1,
+			OriginAirport:      airports[i%len(airports)],
+			DestinationAirport: airports[(i+1)%len(airports)],
+			Xp:                 555,
+		}
+	}
+	return flights
+}
+
+// Internal function that generates n logbook ATC sessions, one a day going back from fixtureTime
+func loggedAtcSessions(n int) []golive.LoggedAtcSession {
+	sessions := make([]golive.LoggedAtcSession, n)
+	for i := range sessions {
+		start := fixtureTime.AddDate(0, 0, -i)
+		sessions[i] = golive.LoggedAtcSession{
+			Id:             fmt.Sprintf("c0000000-0000-4000-8000-%012d", i+1),
+			SessionGroupId: fmt.Sprintf("d0000000-0000-4000-8000-%012d", i+1),
+			Facility: golive.AtcFacility{
+				Id: "a1b2c3d4-0000-4000-8000-000000000001", Icao: "KLAX",
+				Latitude: 33.9425, Longitude: -118.4081, Type: golive.FacilityTower,
+			},
+			Created:    golive.Time(start),
+			Updated:    golive.Time(start.Add(90 * time.Minute)),
+			Operations: 120 + i,
+			TotalTime:  90,
+		}
+	}
+	return sessions
+}
@@ -0,0 +1,389 @@
+// Package golivetest provides an in-process fake of the Infinite Flight Live API
+// for testing code built on golive without network access.
+package golivetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+)
+
+// BasePath is the path prefix the fake serves the v2 API under.
+const BasePath = "/public/v2/"
+
+// Server is a fake Live API serving a set of Fixtures.
+// It is safe for concurrent use, fixtures may be changed between requests with Update.
+type Server struct {
+	*httptest.Server
+
+	// APIKey, if set, is required in the Authorization header of every request.
+	APIKey string
+
+	mutex    sync.Mutex
+	fixtures *Fixtures
+	faults   []*Fault
+	latency  time.Duration
+	requests []string
+}
+
+// Fault makes the server misbehave for requests matching Path.
+type Fault struct {
+	// Path is a path.Match pattern relative to BasePath, such as "sessions/*/flights".
+	// An empty pattern matches every request.
+	Path string
+	// Latency delays the response.
+	Latency time.Duration
+	// Status, if set, is returned as the HTTP status code along with Body.
+	Status int
+	Body   string
+	// ApiError, if set, is returned in the response envelope with a 200 status.
+	ApiError golive.ApiError
+	// Times limits how many requests the fault applies to, zero means unlimited.
+	Times int
+}
+
+// NewServer starts a fake Live API serving fixtures, or DefaultFixtures if nil.
+// The caller should Close it when finished.
+func NewServer(fixtures *Fixtures) *Server {
+	if fixtures == nil {
+		fixtures = DefaultFixtures()
+	}
+	s := &Server{fixtures: fixtures}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client creates a golive.Client pointed at the server.
+func (s *Server) Client(opts ...golive.Option) *golive.Client {
+	opts = append([]golive.Option{golive.WithBaseURL(s.URL + BasePath)}, opts...)
+	return golive.NewClient(s.APIKey, s.Server.Client(), opts...)
+}
+
+// Update calls fn with the server's fixtures while holding its lock.
+func (s *Server) Update(fn func(f *Fixtures)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	fn(s.fixtures)
+}
+
+// Inject adds a fault, faults are checked in the order they were added.
+func (s *Server) Inject(fault Fault) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.faults = nil
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.latency = d
+}
+
+// Requests returns the method and path, relative to BasePath, of every request served so far.
+func (s *Server) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	route := strings.TrimPrefix(r.URL.Path, BasePath)
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, r.Method+" "+route)
+	latency := s.latency
+	fault := s.matchFault(route)
+	s.mutex.Unlock()
+
+	if fault != nil {
+		latency += fault.Latency
+	}
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if s.APIKey != "" && r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		writeEnvelope(w, golive.ErrUnauthorized, nil)
+		return
+	}
+
+	if fault != nil && fault.Status != 0 {
+		w.WriteHeader(fault.Status)
+		w.Write([]byte(fault.Body))
+		return
+	}
+	if fault != nil && fault.ApiError != 0 {
+		writeEnvelope(w, fault.ApiError, nil)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.route(w, r, strings.Split(strings.Trim(route, "/"), "/"))
+}
+
+// Internal method that finds and consumes the first fault matching route, must hold the mutex
+func (s *Server) matchFault(route string) *Fault {
+	for i, fault := range s.faults {
+		if fault.Path != "" {
+			if ok, _ := path.Match(fault.Path, route); !ok {
+				continue
+			}
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// Internal method that dispatches a request to its endpoint, must hold the mutex
+func (s *Server) route(w http.ResponseWriter, r *http.Request, parts []string) {
+	f := s.fixtures
+	get := r.Method == http.MethodGet
+
+	switch {
+	case get && match(parts, "sessions"):
+		writeEnvelope(w, 0, f.Sessions)
+	case get && match(parts, "sessions", "*"):
+		session, ok := f.session(parts[1])
+		if !ok {
+			writeEnvelope(w, golive.ErrServerNotFound, nil)
+			return
+		}
+		writeEnvelope(w, 0, session)
+	case get && match(parts, "sessions", "*", "*"):
+		if _, ok := f.session(parts[1]); !ok {
+			writeEnvelope(w, golive.ErrServerNotFound, nil)
+			return
+		}
+		switch parts[2] {
+		case "flights":
+			writeEnvelope(w, 0, nonNil(f.Flights[parts[1]]))
+		case "atc":
+			writeEnvelope(w, 0, nonNil(f.Atc[parts[1]]))
+		case "world":
+			writeEnvelope(w, 0, nonNil(f.World[parts[1]]))
+		case "notams":
+			writeEnvelope(w, 0, nonNil(f.Notams[parts[1]]))
+		default:
+			http.NotFound(w, r)
+		}
+	case get && (match(parts, "sessions", "*", "flights", "*") || match(parts, "sessions", "*", "flights", "*", "*")):
+		flight, ok := f.flight(parts[1], parts[3])
+		if !ok {
+			writeEnvelope(w, golive.ErrFlightNotFound, nil)
+			return
+		}
+		if len(parts) == 4 {
+			writeEnvelope(w, 0, flight)
+			return
+		}
+		switch parts[4] {
+		case "route":
+			writeEnvelope(w, 0, nonNil(f.Routes[flight.Id]))
+		case "flightplan":
+			plan, ok := f.FlightPlans[flight.Id]
+			if !ok {
+				writeEnvelope(w, golive.ErrFlightNotFound, nil)
+				return
+			}
+			writeEnvelope(w, 0, plan)
+		default:
+			http.NotFound(w, r)
+		}
+	case get && match(parts, "sessions", "*", "airport", "*", "*"):
+		if _, ok := f.session(parts[1]); !ok {
+			writeEnvelope(w, golive.ErrServerNotFound, nil)
+			return
+		}
+		icao := strings.ToUpper(parts[3])
+		switch parts[4] {
+		case "atis":
+			atis, ok := f.Atis[parts[1]][icao]
+			if !ok {
+				writeEnvelope(w, golive.ErrNoAtis, nil)
+				return
+			}
+			writeEnvelope(w, 0, atis)
+		case "status":
+			writeEnvelope(w, 0, f.airportStatus(parts[1], icao))
+		default:
+			http.NotFound(w, r)
+		}
+	case r.Method == http.MethodPost && match(parts, "users"):
+		s.users(w, r)
+	case get && match(parts, "users", "*"):
+		grade, ok := f.Grades[parts[1]]
+		if !ok {
+			writeEnvelope(w, golive.ErrUserNotFound, nil)
+			return
+		}
+		writeEnvelope(w, 0, grade)
+	case get && match(parts, "users", "*", "flights"):
+		writeEnvelope(w, 0, paginate(f.UserFlights[parts[1]], r, f.PageSize))
+	case get && match(parts, "users", "*", "flights", "*"):
+		for _, flight := range f.UserFlights[parts[1]] {
+			if flight.Id == parts[3] {
+				writeEnvelope(w, 0, flight)
+				return
+			}
+		}
+		writeEnvelope(w, golive.ErrFlightNotFound, nil)
+	case get && match(parts, "users", "*", "atc"):
+		writeEnvelope(w, 0, paginate(f.UserAtcSessions[parts[1]], r, f.PageSize))
+	case get && match(parts, "users", "*", "atc", "*"):
+		for _, session := range f.UserAtcSessions[parts[1]] {
+			if session.Id == parts[3] {
+				writeEnvelope(w, 0, session)
+				return
+			}
+		}
+		writeEnvelope(w, golive.ErrEndpoint, nil)
+	case get && match(parts, "tracks"):
+		writeEnvelope(w, 0, nonNil(f.Tracks))
+	case get && match(parts, "aircraft"):
+		writeEnvelope(w, 0, nonNil(f.Aircraft))
+	case get && match(parts, "aircraft", "liveries"):
+		writeEnvelope(w, 0, nonNil(f.Liveries))
+	case get && match(parts, "aircraft", "*", "liveries"):
+		liveries := []golive.Livery{}
+		for _, livery := range f.Liveries {
+			if livery.AircraftID == parts[1] {
+				liveries = append(liveries, livery)
+			}
+		}
+		writeEnvelope(w, 0, liveries)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Internal method that serves the users endpoint, must hold the mutex
+func (s *Server) users(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		UserIds        []string `json:"userIds"`
+		DiscourseNames []string `json:"discourseNames"`
+		UserHashes     []string `json:"userHashes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeEnvelope(w, golive.ErrMissingParameters, nil)
+		return
+	}
+	total := len(body.UserIds) + len(body.DiscourseNames) + len(body.UserHashes)
+	if total == 0 {
+		writeEnvelope(w, golive.ErrMissingParameters, nil)
+		return
+	}
+	if total > golive.MaxUsersPerRequest {
+		http.Error(w, "too many users requested", http.StatusBadRequest)
+		return
+	}
+
+	// Unknown identifiers get an entry carrying ErrUserNotFound
+	result := make([]golive.UserStats, 0, total)
+	for _, id := range body.UserIds {
+		stats, ok := s.fixtures.user(func(u golive.UserStats) bool { return strings.EqualFold(u.UserId, id) })
+		if !ok {
+			stats = golive.UserStats{UserId: id, ErrorCode: int(golive.ErrUserNotFound)}
+		}
+		result = append(result, stats)
+	}
+	for _, name := range body.DiscourseNames {
+		stats, ok := s.fixtures.user(func(u golive.UserStats) bool { return strings.EqualFold(u.DiscourseUsername, name) })
+		if !ok {
+			stats = golive.UserStats{DiscourseUsername: name, ErrorCode: int(golive.ErrUserNotFound)}
+		}
+		result = append(result, stats)
+	}
+	for _, hash := range body.UserHashes {
+		stats, ok := s.fixtures.user(func(u golive.UserStats) bool { return strings.EqualFold(u.Hash, hash) })
+		if !ok {
+			stats = golive.UserStats{Hash: hash, ErrorCode: int(golive.ErrUserNotFound)}
+		}
+		result = append(result, stats)
+	}
+	writeEnvelope(w, 0, result)
+}
+
+// Internal function that reports whether path parts match a pattern where "*" matches any part
+func match(parts []string, pattern ...string) bool {
+	if len(parts) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Internal function that returns one page of a logbook, pages are numbered from 1
+func paginate[T any](items []T, r *http.Request, pageSize int) golive.LogbookPage[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	totalPages := (len(items) + pageSize - 1) / pageSize
+	data := []T{}
+	if start := (page - 1) * pageSize; start < len(items) {
+		end := start + pageSize
+		if end > len(items) {
+			end = len(items)
+		}
+		data = items[start:end]
+	}
+	return golive.LogbookPage[T]{
+		PageIndex:       page,
+		TotalPages:      totalPages,
+		TotalCount:      len(items),
+		HasPreviousPage: page > 1,
+		HasNextPage:     page < totalPages,
+		Data:            data,
+	}
+}
+
+// Internal function that makes sure empty lists are encoded as [] like the real API does
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// Internal function that writes a Live API response envelope
+func writeEnvelope(w http.ResponseWriter, code golive.ApiError, result any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(struct {
+		ErrorCode int `json:"errorCode"`
+		Result    any `json:"result"`
+	}{int(code), result})
+}
@@ -0,0 +1,121 @@
+package golivetest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+)
+
+func TestFaults(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+
+	server.Inject(Fault{Path: "sessions/*/flights", ApiError: golive.ErrServerNotFound, Times: 1})
+	server.Inject(Fault{Path: "aircraft", Status: http.StatusBadGateway, Body: "<html>Bad Gateway</html>"})
+
+	if _, err := client.GetFlights(ExpertSessionId); !errors.Is(err, golive.ErrServerNotFound) {
+		t.Errorf("expected injected ErrServerNotFound, got %v", err)
+	}
+	if flights, err := client.GetFlights(ExpertSessionId); err != nil || len(flights) != 3 {
+		t.Errorf("expected fault to be used up, got %v and %d flights", err, len(flights))
+	}
+
+	var httpErr *golive.HTTPError
+	if _, err := client.GetAircraft(); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected injected 502, got %v", err)
+	}
+	server.ClearFaults()
+	if _, err := client.GetAircraft(); err != nil {
+		t.Error(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 4 || requests[0] != "GET sessions/"+ExpertSessionId+"/flights" {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	server.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := server.Client().GetSessionsCtx(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+
+	if _, err := client.GetFlights("nope"); !errors.Is(err, golive.ErrServerNotFound) {
+		t.Errorf("expected ErrServerNotFound, got %v", err)
+	}
+	if _, err := client.GetFlightPlan(ExpertSessionId, SouthwestFlightId); !errors.Is(err, golive.ErrFlightNotFound) {
+		t.Errorf("expected ErrFlightNotFound, got %v", err)
+	}
+	if _, err := client.GetAtis(ExpertSessionId, "KSFO"); !errors.Is(err, golive.ErrNoAtis) {
+		t.Errorf("expected ErrNoAtis, got %v", err)
+	}
+	if _, err := client.GetUserGrade("nobody"); !errors.Is(err, golive.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	server.APIKey = "secret"
+
+	if _, err := server.Client().GetSessions(); err != nil {
+		t.Error(err)
+	}
+	other := golive.NewClient("wrong", nil, golive.WithBaseURL(server.URL+BasePath))
+	if _, err := other.GetSessions(); !errors.Is(err, golive.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+
+	page, err := client.GetUserFlights(KaiUserId, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.PageIndex != 3 || page.TotalPages != 3 || page.TotalCount != 23 || len(page.Data) != 3 ||
+		page.HasNextPage || !page.HasPreviousPage {
+		t.Errorf("unexpected last page %+v", page)
+	}
+
+	server.Update(func(f *Fixtures) { f.PageSize = 50 })
+	page, err = client.GetUserFlights(KaiUserId, 1)
+	if err != nil || len(page.Data) != 23 || page.HasNextPage {
+		t.Errorf("unexpected single page %+v, %v", page, err)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	server := NewServer(nil)
+	defer server.Close()
+
+	stats, err := server.Client().GetUserStats([]string{KaiUserId}, []string{"laura", "ghost"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 3 || stats[0].DiscourseUsername != "KaiM" || stats[1].UserId != LauraUserId ||
+		stats[2].ErrorCode != int(golive.ErrUserNotFound) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,157 @@
+package golive
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sqeezelemon/golive/geo"
+)
+
+// Touchdown is an estimated landing of a flight.
+type Touchdown struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	// VerticalSpeed in feet per minute just before touchdown, negative when descending.
+	VerticalSpeed float64
+	// GroundSpeed in knots at touchdown
+	GroundSpeed float64
+	// Airport is the nearest airport within LandingOptions.MaxAirportDistance, nil if there is none.
+	Airport *Airport
+	// AirportDistance is the distance to Airport in nautical miles.
+	AirportDistance float64
+}
+
+// Position returns the position of the touchdown.
+func (t Touchdown) Position() geo.Point {
+	return geo.Point{Latitude: t.Latitude, Longitude: t.Longitude}
+}
+
+// LandingOptions controls how touchdowns are detected.
+type LandingOptions struct {
+	// Airports to match touchdowns with, such as from AirportsFromAtc or AirportsFromFlightPlan.
+	Airports []Airport
+	// MaxAirportDistance in nautical miles, beyond which a touchdown is not matched with an airport, 5 if zero.
+	MaxAirportDistance float64
+	// Phases tunes the thresholds telling flight from ground, nil uses the defaults.
+	// LandingDetector takes its Elevation from the nearest airport if it is zero.
+	Phases *PhaseOptions
+}
+
+// Internal function that fills in default options
+func (o *LandingOptions) withDefaults() LandingOptions {
+	var options LandingOptions
+	if o != nil {
+		options = *o
+	}
+	if options.MaxAirportDistance <= 0 {
+		options.MaxAirportDistance = 5
+	}
+	return options
+}
+
+// Internal method that returns a touchdown at a position matched with the nearest airport
+func (o LandingOptions) touchdown(at time.Time, p geo.Point, verticalSpeed float64, groundSpeed float64) Touchdown {
+	touchdown := Touchdown{
+		Time:          at,
+		Latitude:      p.Latitude,
+		Longitude:     p.Longitude,
+		VerticalSpeed: verticalSpeed,
+		GroundSpeed:   groundSpeed,
+	}
+	touchdown.Airport, touchdown.AirportDistance = nearestAirport(p, o.Airports, o.MaxAirportDistance)
+	return touchdown
+}
+
+// DetectTouchdowns finds the landings in a route from GetFlightRoute, in order. A nil options uses the defaults.
+// Routes are too sparse to show the flare, so the touchdown is extrapolated from the last report in flight
+// at its descent rate, which is also the vertical speed reported, and the ground speed is interpolated.
+// Touch and goes only show up if a report was made on the runway.
+func DetectTouchdowns(route []PositionReport, options *LandingOptions) []Touchdown {
+	o := options.withDefaults()
+	phases := o.Phases.withDefaults().routePhases(route)
+
+	var touchdowns []Touchdown
+	for i := 1; i < len(route); i++ {
+		if !phases[i-1].airborne() || phases[i].airborne() {
+			continue
+		}
+		last, ground := route[i-1], route[i]
+		elapsed := ground.Date.Time().Sub(last.Date.Time())
+
+		// Descent rate over the last interval in flight
+		rate := verticalSpeed(route[:i], i-1)
+		fraction := 1.0
+		if rate < 0 && elapsed > 0 {
+			minutes := (last.Altitude - ground.Altitude) / -rate
+			if f := minutes / elapsed.Minutes(); f < 1 {
+				fraction = f
+			}
+		} else if elapsed > 0 {
+			rate = (ground.Altitude - last.Altitude) / elapsed.Minutes()
+		}
+		if fraction < 0 {
+			fraction = 0
+		}
+
+		at := last.Date.Time().Add(time.Duration(fraction * float64(elapsed)))
+		p := geo.Destination(last.Position(), geo.InitialBearing(last.Position(), ground.Position()),
+			fraction*geo.Distance(last.Position(), ground.Position()))
+		speed := last.GroundSpeed + fraction*(ground.GroundSpeed-last.GroundSpeed)
+		touchdowns = append(touchdowns, o.touchdown(at, p, rate, speed))
+	}
+	return touchdowns
+}
+
+// LandingDetector detects touchdowns from flights polled regularly, such as from a Watcher.
+// It is safe for concurrent use.
+type LandingDetector struct {
+	options LandingOptions
+
+	mutex sync.Mutex
+	// Last snapshot in flight of every flight that is airborne
+	airborne map[string]Flight
+}
+
+// NewLandingDetector creates a detector. A nil options uses the defaults.
+// Give it airports with their elevation, otherwise landings are only noticed once the flight slows to taxi speed,
+// unless the airport is near sea level.
+func NewLandingDetector(options *LandingOptions) *LandingDetector {
+	return &LandingDetector{
+		options:  options.withDefaults(),
+		airborne: map[string]Flight{},
+	}
+}
+
+// Update records a snapshot of a flight and returns its touchdown if it landed since the previous snapshot.
+// The touchdown takes its vertical speed and ground speed from the last snapshot in flight,
+// and its time and position from the first one on the ground.
+func (d *LandingDetector) Update(f Flight) (Touchdown, bool) {
+	phases := d.options.Phases.withDefaults()
+	if phases.Elevation == 0 {
+		if airport, _ := nearestAirport(f.Position(), d.options.Airports, d.options.MaxAirportDistance); airport != nil {
+			phases.Elevation = airport.Elevation
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	previous, wasAirborne := d.airborne[f.Id]
+	phase := phases.classify(f.Altitude-phases.Elevation, f.Speed, f.VerticalSpeed, wasAirborne)
+	if phase.airborne() {
+		d.airborne[f.Id] = f
+		return Touchdown{}, false
+	}
+	delete(d.airborne, f.Id)
+	if !wasAirborne {
+		return Touchdown{}, false
+	}
+	return d.options.touchdown(f.LastReport.Time(), f.Position(), previous.VerticalSpeed, previous.Speed), true
+}
+
+// Forget drops the state of a flight, such as when it despawns.
+func (d *LandingDetector) Forget(flightId string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.airborne, flightId)
+}
@@ -0,0 +1,163 @@
+package golive_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/geo"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+// Threshold of runway 28R at KSFO and its course
+var (
+	ksfo28R      = geo.Point{Latitude: 37.6133, Longitude: -122.3571}
+	ksfo28RTrack = 284.0
+)
+
+func TestAirports(t *testing.T) {
+	fixtures := golivetest.DefaultFixtures()
+	airports := golive.AirportsFromAtc(fixtures.Atc[golivetest.ExpertSessionId])
+	if len(airports) != 2 || airports[0].Icao != "KLAX" || airports[1].Icao != "KSFO" {
+		t.Errorf("unexpected airports %+v", airports)
+	}
+	airports = golive.AirportsFromFlightPlan(fixtures.FlightPlans[golivetest.DeltaFlightId])
+	if len(airports) != 2 || airports[0].Icao != "KLAX" || airports[0].Elevation != 125 || airports[1].Icao != "KSFO" {
+		t.Errorf("unexpected airports %+v", airports)
+	}
+}
+
+func TestDetectTouchdowns(t *testing.T) {
+	start := time.Date(2022, 10, 1, 19, 0, 0, 0, time.UTC)
+	const elevation = 13
+	var route []golive.PositionReport
+	report := func(seconds float64, height float64, speed float64) {
+		// Negative times are before the threshold, positive ones past it along the runway
+		distance := speed * seconds / 3600
+		position := geo.Destination(ksfo28R, ksfo28RTrack, distance)
+		route = append(route, golive.PositionReport{
+			Latitude:    position.Latitude,
+			Longitude:   position.Longitude,
+			Altitude:    elevation + height,
+			GroundSpeed: speed,
+			Track:       ksfo28RTrack,
+			Date:        golive.Time(start.Add(time.Duration(seconds) * time.Second)),
+		})
+	}
+	// Final approach at 700 feet per minute, the last report in flight is at 105 feet
+	for seconds := -180.0; seconds <= 0; seconds += 15 {
+		report(seconds, 105-700*seconds/60, 140)
+	}
+	report(15, 0, 120)
+	report(30, 0, 90)
+	report(45, 0, 50)
+	for seconds := 60.0; seconds <= 120; seconds += 15 {
+		report(seconds, 0, 20)
+	}
+
+	plan := golivetest.DefaultFixtures().FlightPlans[golivetest.DeltaFlightId]
+	options := &golive.LandingOptions{Airports: golive.AirportsFromFlightPlan(plan)}
+	touchdowns := golive.DetectTouchdowns(route, options)
+	if len(touchdowns) != 1 {
+		t.Fatalf("expected one touchdown, got %+v", touchdowns)
+	}
+	touchdown := touchdowns[0]
+	if math.Abs(touchdown.VerticalSpeed+700) > 1 {
+		t.Errorf("expected 700 feet per minute down, got %.1f", touchdown.VerticalSpeed)
+	}
+	// 105 feet at 700 feet per minute take 9 seconds
+	if at := touchdown.Time.Sub(start); at < 8*time.Second || at > 10*time.Second {
+		t.Errorf("expected a touchdown 9 seconds past the threshold, got %v", at)
+	}
+	if math.Abs(touchdown.GroundSpeed-128) > 1 {
+		t.Errorf("expected about 128 knots, got %.1f", touchdown.GroundSpeed)
+	}
+	if touchdown.Airport == nil || touchdown.Airport.Icao != "KSFO" || touchdown.AirportDistance > 2 {
+		t.Errorf("expected a touchdown at KSFO, got %+v %.1f nm away", touchdown.Airport, touchdown.AirportDistance)
+	}
+	if d := geo.Distance(ksfo28R, touchdown.Position()); d > 0.5 {
+		t.Errorf("touchdown is %.2f nm from the threshold", d)
+	}
+
+	// Far from any airport
+	touchdowns = golive.DetectTouchdowns(route, &golive.LandingOptions{Airports: []golive.Airport{{Icao: "KLAX", Latitude: 33.9425, Longitude: -118.4081}}})
+	if len(touchdowns) != 1 || touchdowns[0].Airport != nil {
+		t.Errorf("expected an unmatched touchdown, got %+v", touchdowns)
+	}
+
+	// The fixture route only climbs out
+	if touchdowns := golive.DetectTouchdowns(golivetest.DefaultFixtures().Routes[golivetest.DeltaFlightId], nil); len(touchdowns) != 0 {
+		t.Errorf("expected no touchdown, got %+v", touchdowns)
+	}
+}
+
+func TestDetectTouchdownsSameDate(t *testing.T) {
+	const elevation = 13
+	// Level at 500 feet, then a report on the runway made at the same time as the last one in flight
+	route := syntheticRoute(elevation+500, [3]float64{2, 140, 0})
+	last := route[len(route)-1]
+	route = append(route, golive.PositionReport{Altitude: elevation, GroundSpeed: 120, Date: last.Date})
+
+	touchdowns := golive.DetectTouchdowns(route, &golive.LandingOptions{Phases: &golive.PhaseOptions{Elevation: elevation}})
+	if len(touchdowns) != 1 {
+		t.Fatalf("expected one touchdown, got %+v", touchdowns)
+	}
+	touchdown := touchdowns[0]
+	if math.IsNaN(touchdown.VerticalSpeed) || math.IsInf(touchdown.VerticalSpeed, 0) {
+		t.Errorf("expected a finite vertical speed, got %v", touchdown.VerticalSpeed)
+	}
+	if !touchdown.Time.Equal(last.Date.Time()) {
+		t.Errorf("expected the touchdown at %v, got %v", last.Date.Time(), touchdown.Time)
+	}
+}
+
+func TestLandingDetector(t *testing.T) {
+	plan := golivetest.DefaultFixtures().FlightPlans[golivetest.DeltaFlightId]
+	detector := golive.NewLandingDetector(&golive.LandingOptions{Airports: golive.AirportsFromFlightPlan(plan)})
+	start := time.Date(2022, 10, 1, 19, 0, 0, 0, time.UTC)
+
+	snapshot := func(seconds float64, height float64, speed float64, verticalSpeed float64) golive.Flight {
+		position := geo.Destination(ksfo28R, ksfo28RTrack, speed*seconds/3600)
+		return golive.Flight{
+			Id:            golivetest.DeltaFlightId,
+			Latitude:      position.Latitude,
+			Longitude:     position.Longitude,
+			Altitude:      13 + height,
+			Speed:         speed,
+			VerticalSpeed: verticalSpeed,
+			LastReport:    golive.Time(start.Add(time.Duration(seconds) * time.Second)),
+		}
+	}
+	snapshots := []golive.Flight{
+		snapshot(-30, 430, 141, -720),
+		snapshot(-15, 260, 139, -680),
+		snapshot(0, 90, 138, -640),
+		snapshot(15, 2, 118, -15),
+		snapshot(30, 1, 80, 0),
+		snapshot(45, 1, 25, 0),
+	}
+	var touchdowns []golive.Touchdown
+	for _, flight := range snapshots {
+		if touchdown, ok := detector.Update(flight); ok {
+			touchdowns = append(touchdowns, touchdown)
+		}
+	}
+	if len(touchdowns) != 1 {
+		t.Fatalf("expected one touchdown, got %+v", touchdowns)
+	}
+	touchdown := touchdowns[0]
+	if touchdown.VerticalSpeed != -640 || touchdown.GroundSpeed != 138 {
+		t.Errorf("expected -640 feet per minute at 138 knots, got %.0f at %.0f", touchdown.VerticalSpeed, touchdown.GroundSpeed)
+	}
+	if !touchdown.Time.Equal(start.Add(15*time.Second)) || touchdown.Airport == nil || touchdown.Airport.Icao != "KSFO" {
+		t.Errorf("unexpected touchdown %+v", touchdown)
+	}
+
+	// A forgotten flight does not land
+	detector.Update(snapshots[0])
+	detector.Forget(golivetest.DeltaFlightId)
+	if touchdown, ok := detector.Update(snapshots[3]); ok {
+		t.Errorf("unexpected touchdown %+v", touchdown)
+	}
+}
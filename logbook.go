@@ -0,0 +1,181 @@
+package golive
+
+import (
+	"context"
+	"time"
+)
+
+// LogbookOptions controls which pages of a logbook an iterator walks.
+type LogbookOptions struct {
+	// StartPage is the first page fetched, pages are numbered from 1.
+	StartPage int
+	// MaxPages limits the number of pages fetched, zero means no limit.
+	MaxPages int
+	// Since stops the iteration at the first entry created before it.
+	// Logbooks are ordered newest first, so only entries created at or after Since are returned.
+	Since time.Time
+	// Prefetch fetches the next page in the background while the current one is consumed.
+	Prefetch bool
+}
+
+// LogbookIterator walks the entries of a paginated logbook in order.
+// It is not safe for concurrent use.
+//
+//	it := client.IterUserFlights(ctx, userId, nil)
+//	defer it.Close()
+//	for it.Next() {
+//		flight := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type LogbookIterator[T any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	fetch   func(ctx context.Context, page int) (LogbookPage[T], error)
+	created func(T) time.Time
+	options LogbookOptions
+
+	page     LogbookPage[T]
+	index    int
+	fetched  int
+	pending  chan logbookResult[T]
+	value    T
+	err      error
+	finished bool
+}
+
+type logbookResult[T any] struct {
+	page LogbookPage[T]
+	err  error
+}
+
+func newLogbookIterator[T any](
+	ctx context.Context,
+	fetch func(ctx context.Context, page int) (LogbookPage[T], error),
+	created func(T) time.Time,
+	options *LogbookOptions,
+) *LogbookIterator[T] {
+	it := &LogbookIterator[T]{fetch: fetch, created: created}
+	if options != nil {
+		it.options = *options
+	}
+	if it.options.StartPage < 1 {
+		it.options.StartPage = 1
+	}
+	it.ctx, it.cancel = context.WithCancel(ctx)
+	return it
+}
+
+// IterUserFlights returns an iterator over the flight logbook of a user.
+// A nil options walks every page from the first one.
+func (c *Client) IterUserFlights(ctx context.Context, userId string, options *LogbookOptions) *LogbookIterator[LoggedFlight] {
+	fetch := func(ctx context.Context, page int) (LogbookPage[LoggedFlight], error) {
+		result, err := c.GetUserFlightsCtx(ctx, userId, page)
+		return LogbookPage[LoggedFlight](result), err
+	}
+	created := func(flight LoggedFlight) time.Time {
+		return flight.Created.Time()
+	}
+	return newLogbookIterator(ctx, fetch, created, options)
+}
+
+// IterUserAtcSessions returns an iterator over the ATC logbook of a user.
+// A nil options walks every page from the first one.
+func (c *Client) IterUserAtcSessions(ctx context.Context, userId string, options *LogbookOptions) *LogbookIterator[LoggedAtcSession] {
+	fetch := func(ctx context.Context, page int) (LogbookPage[LoggedAtcSession], error) {
+		result, err := c.GetUserAtcSessionsCtx(ctx, userId, page)
+		return LogbookPage[LoggedAtcSession](result), err
+	}
+	created := func(session LoggedAtcSession) time.Time {
+		return session.Created.Time()
+	}
+	return newLogbookIterator(ctx, fetch, created, options)
+}
+
+// Next advances to the next entry, fetching pages as needed.
+// It returns false when the logbook is exhausted, a limit is reached or an error occurs.
+func (it *LogbookIterator[T]) Next() bool {
+	if it.finished {
+		return false
+	}
+	for it.index >= len(it.page.Data) {
+		if !it.nextPage() {
+			it.Close()
+			return false
+		}
+	}
+
+	value := it.page.Data[it.index]
+	it.index++
+	if !it.options.Since.IsZero() {
+		if created := it.created(value); !created.IsZero() && created.Before(it.options.Since) {
+			it.Close()
+			return false
+		}
+	}
+	it.value = value
+	return true
+}
+
+// Value returns the current entry.
+func (it *LogbookIterator[T]) Value() T {
+	return it.value
+}
+
+// Page returns the most recently fetched page, without its entries being consumed.
+func (it *LogbookIterator[T]) Page() LogbookPage[T] {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *LogbookIterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and cancels any page being prefetched.
+func (it *LogbookIterator[T]) Close() {
+	it.finished = true
+	it.cancel()
+}
+
+// Internal method that replaces the current page with the next one
+func (it *LogbookIterator[T]) nextPage() bool {
+	if it.fetched > 0 && !it.page.HasNextPage {
+		return false
+	}
+	if it.options.MaxPages > 0 && it.fetched >= it.options.MaxPages {
+		return false
+	}
+
+	number := it.options.StartPage + it.fetched
+	var result logbookResult[T]
+	if it.pending != nil {
+		result = <-it.pending
+		it.pending = nil
+	} else {
+		result.page, result.err = it.fetch(it.ctx, number)
+	}
+	if result.err != nil {
+		it.err = result.err
+		return false
+	}
+
+	it.page = result.page
+	it.index = 0
+	it.fetched++
+	if len(it.page.Data) == 0 {
+		return false
+	}
+
+	if it.options.Prefetch && it.page.HasNextPage && (it.options.MaxPages == 0 || it.fetched < it.options.MaxPages) {
+		pending := make(chan logbookResult[T], 1)
+		go func(ctx context.Context, number int) {
+			var result logbookResult[T]
+			result.page, result.err = it.fetch(ctx, number)
+			pending <- result
+		}(it.ctx, number+1)
+		it.pending = pending
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package golive_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func collectFlights(t *testing.T, it *golive.LogbookIterator[golive.LoggedFlight]) []golive.LoggedFlight {
+	t.Helper()
+	defer it.Close()
+	var flights []golive.LoggedFlight
+	for it.Next() {
+		flights = append(flights, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return flights
+}
+
+func TestIterUserFlights(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	client := server.Client()
+
+	flights := collectFlights(t, client.IterUserFlights(context.Background(), golivetest.KaiUserId, nil))
+	if len(flights) != 23 || flights[0].Id == flights[22].Id {
+		t.Fatalf("expected all 23 flights, got %d", len(flights))
+	}
+
+	limited := collectFlights(t, client.IterUserFlights(context.Background(), golivetest.KaiUserId, &golive.LogbookOptions{
+		StartPage: 2,
+		MaxPages:  1,
+	}))
+	if len(limited) != 10 || limited[0].Id != flights[10].Id {
+		t.Errorf("expected the 10 flights of page 2, got %d", len(limited))
+	}
+
+	since := flights[4].Created.Time()
+	recent := collectFlights(t, client.IterUserFlights(context.Background(), golivetest.KaiUserId, &golive.LogbookOptions{
+		Since: since,
+	}))
+	if len(recent) != 5 {
+		t.Errorf("expected 5 flights since %v, got %d", since, len(recent))
+	}
+}
+
+func TestIterPrefetch(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+
+	it := server.Client().IterUserAtcSessions(context.Background(), golivetest.LauraUserId, &golive.LogbookOptions{Prefetch: true})
+	defer it.Close()
+	if !it.Next() {
+		t.Fatal(it.Err())
+	}
+	// Wait for the second page to be requested while the first one is still being consumed
+	deadline := time.Now().Add(time.Second)
+	for len(server.Requests()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	requests := server.Requests()
+	if len(requests) != 2 || !strings.HasSuffix(requests[1], "/atc") {
+		t.Fatalf("expected page 2 to be prefetched, got %v", requests)
+	}
+
+	count := 1
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil || count != 12 {
+		t.Errorf("expected 12 sessions, got %d and %v", count, it.Err())
+	}
+	if len(server.Requests()) != 2 {
+		t.Errorf("expected no further requests, got %v", server.Requests())
+	}
+}
+
+func TestIterError(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+
+	it := server.Client().IterUserFlights(context.Background(), golivetest.KaiUserId, nil)
+	defer it.Close()
+	count := 0
+	for it.Next() {
+		count++
+		if count == 10 {
+			server.Inject(golivetest.Fault{Status: http.StatusServiceUnavailable})
+		}
+	}
+	var httpErr *golive.HTTPError
+	if count != 10 || !errors.As(it.Err(), &httpErr) {
+		t.Errorf("expected to stop after page 1 with an HTTPError, got %d entries and %v", count, it.Err())
+	}
+	if it.Next() {
+		t.Error("expected iterator to stay finished")
+	}
+}
@@ -0,0 +1,41 @@
+package golive
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithBaseURL points the client at a different Live API root,
+// such as a staging mirror, a caching proxy or an httptest.Server.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseUrl = strings.TrimRight(url, "/") + "/"
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header sent with every request.
+// The Authorization header is always set from the API key and cannot be overridden.
+func WithHeader(key string, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithHTTPClient replaces the http.Client used to perform requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package golive
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	c := fakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/public/v2/aircraft" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "golive-test/1.0" {
+			t.Errorf("unexpected User-Agent %q", ua)
+		}
+		if v := r.Header.Values("X-Trace"); len(v) != 2 {
+			t.Errorf("unexpected X-Trace values %v", v)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer key" {
+			t.Errorf("unexpected Authorization header %q", auth)
+		}
+		w.Write([]byte(`{"errorCode":0,"result":[]}`))
+	},
+		WithUserAgent("golive-test/1.0"),
+		WithHeader("X-Trace", "a"),
+		WithHeader("X-Trace", "b"),
+		WithHeader("Authorization", "Bearer other"),
+	)
+
+	if _, err := c.GetAircraft(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	c := NewClient("key", nil)
+	if c.baseUrl != DefaultBaseURL {
+		t.Errorf("unexpected base URL %q", c.baseUrl)
+	}
+	if c.client != http.DefaultClient {
+		t.Error("expected http.DefaultClient")
+	}
+
+	custom := &http.Client{}
+	c = NewClient("key", nil, WithBaseURL("http://localhost:8080/v2/"), WithHTTPClient(custom))
+	if c.baseUrl != "http://localhost:8080/v2/" {
+		t.Errorf("unexpected base URL %q", c.baseUrl)
+	}
+	if c.client != custom {
+		t.Error("expected custom http.Client")
+	}
+}
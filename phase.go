@@ -0,0 +1,269 @@
+package golive
+
+import "time"
+
+// FlightPhase is the stage of flight an aircraft is in, as estimated by ClassifyFlight and ClassifyRoute.
+type FlightPhase int
+
+const (
+	PhaseUnknown  FlightPhase = 0
+	PhaseParked   FlightPhase = 1
+	PhaseTaxi     FlightPhase = 2
+	PhaseTakeoff  FlightPhase = 3
+	PhaseClimb    FlightPhase = 4
+	PhaseCruise   FlightPhase = 5
+	PhaseDescent  FlightPhase = 6
+	PhaseApproach FlightPhase = 7
+	PhaseLanded   FlightPhase = 8
+)
+
+var flightPhaseNames = map[int]string{
+	0: "Unknown",
+	1: "Parked",
+	2: "Taxi",
+	3: "Takeoff",
+	4: "Climb",
+	5: "Cruise",
+	6: "Descent",
+	7: "Approach",
+	8: "Landed",
+}
+
+func (p FlightPhase) String() string {
+	return enumString("FlightPhase", flightPhaseNames, int(p))
+}
+
+func (p FlightPhase) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *FlightPhase) UnmarshalText(b []byte) error {
+	return enumUnmarshalText("FlightPhase", flightPhaseNames, b, (*int)(p))
+}
+
+// PhaseOptions tunes the thresholds used to classify flight phases.
+type PhaseOptions struct {
+	// Elevation of the airport in feet, which tells the takeoff roll from flight and approaches from cruise.
+	// If zero, ClassifyRoute estimates it from the reports on the ground and ClassifyFlight uses altitudes as they are.
+	Elevation float64
+	// TaxiSpeed in knots, below which a flight is on the ground, 40 if zero.
+	TaxiSpeed float64
+	// ParkedSpeed in knots, below which a flight on the ground is parked, 1 if zero.
+	ParkedSpeed float64
+	// LevelRate in feet per minute, below which a flight is level, 300 if zero.
+	LevelRate float64
+	// ApproachHeight in feet above the airport, below which a flight that is not climbing is on approach, 3000 if zero.
+	ApproachHeight float64
+	// MinDuration is how long a phase must last to start a segment in ClassifyRoute, 30 seconds if zero.
+	// Shorter phases are merged into the previous segment, except takeoff and landing rolls.
+	MinDuration time.Duration
+}
+
+// Internal function that fills in default options
+func (o *PhaseOptions) withDefaults() PhaseOptions {
+	var options PhaseOptions
+	if o != nil {
+		options = *o
+	}
+	if options.TaxiSpeed <= 0 {
+		options.TaxiSpeed = 40
+	}
+	if options.ParkedSpeed <= 0 {
+		options.ParkedSpeed = 1
+	}
+	if options.LevelRate <= 0 {
+		options.LevelRate = 300
+	}
+	if options.ApproachHeight <= 0 {
+		options.ApproachHeight = 3000
+	}
+	if options.MinDuration <= 0 {
+		options.MinDuration = 30 * time.Second
+	}
+	return options
+}
+
+// Height above the airport under which a flight faster than taxi speed is rolling on the runway
+const runwayHeight = 50
+
+// PhaseSegment is a stretch of a route flown in one phase.
+type PhaseSegment struct {
+	Phase FlightPhase
+	Start time.Time
+	// End is the start of the next segment, or the last report for the last segment.
+	End time.Time
+	// Reports holds the reports of the route in the segment.
+	Reports []PositionReport
+}
+
+// Duration returns the length of the segment.
+func (s PhaseSegment) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// ClassifyFlight estimates the phase of a flight from a single snapshot. A nil options uses the defaults.
+// A snapshot cannot tell a takeoff roll from a landing roll, both are reported as PhaseTakeoff,
+// and without an Elevation option the roll is only recognised at airports near sea level.
+func ClassifyFlight(f Flight, options *PhaseOptions) FlightPhase {
+	o := options.withDefaults()
+	return o.classify(f.Altitude-o.Elevation, f.Speed, f.VerticalSpeed, false)
+}
+
+// ClassifyRoute splits a route from GetFlightRoute into phase segments in order. A nil options uses the defaults.
+// Vertical speeds are derived from the altitudes and dates of the reports,
+// which must be in chronological order.
+func ClassifyRoute(route []PositionReport, options *PhaseOptions) []PhaseSegment {
+	if len(route) == 0 {
+		return nil
+	}
+	o := options.withDefaults()
+	phases := o.routePhases(route)
+
+	// Runs of reports in the same phase, merging runs that are too short into the previous one
+	type run struct {
+		phase FlightPhase
+		start int
+	}
+	var runs []run
+	for start := 0; start < len(route); {
+		end := start + 1
+		for end < len(route) && phases[end] == phases[start] {
+			end++
+		}
+		phase := phases[start]
+		short := end < len(route) &&
+			phase != PhaseTakeoff && phase != PhaseLanded &&
+			route[end].Date.Time().Sub(route[start].Date.Time()) < o.MinDuration
+		if len(runs) == 0 || !short && runs[len(runs)-1].phase != phase {
+			runs = append(runs, run{phase, start})
+		}
+		start = end
+	}
+
+	segments := make([]PhaseSegment, len(runs))
+	for i, r := range runs {
+		end := len(route)
+		if i+1 < len(runs) {
+			end = runs[i+1].start
+		}
+		segments[i] = PhaseSegment{
+			Phase:   r.phase,
+			Start:   route[r.start].Date.Time(),
+			End:     route[end-1].Date.Time(),
+			Reports: route[r.start:end],
+		}
+		if end < len(route) {
+			segments[i].End = route[end].Date.Time()
+		}
+	}
+	return segments
+}
+
+// Internal method that classifies every report of a route on its own
+func (o PhaseOptions) routePhases(route []PositionReport) []FlightPhase {
+	elevations := routeElevations(route, o)
+	phases := make([]FlightPhase, len(route))
+	airborne := false
+	for i, report := range route {
+		phases[i] = o.classify(report.Altitude-elevations[i], report.GroundSpeed, verticalSpeed(route, i), airborne)
+		airborne = phases[i].airborne() || airborne && !phases[i].slow()
+	}
+	return phases
+}
+
+// Internal method that reports whether the phase is in flight
+func (p FlightPhase) airborne() bool {
+	return p == PhaseClimb || p == PhaseCruise || p == PhaseDescent || p == PhaseApproach
+}
+
+// Internal method that reports whether the phase is on the ground below taxi speed
+func (p FlightPhase) slow() bool {
+	return p == PhaseParked || p == PhaseTaxi
+}
+
+// Internal function that returns the vertical speed at a report in feet per minute,
+// from the reports around it
+func verticalSpeed(route []PositionReport, i int) float64 {
+	before, after := i, i
+	if i > 0 {
+		before = i - 1
+	}
+	if i+1 < len(route) {
+		after = i + 1
+	}
+	elapsed := route[after].Date.Time().Sub(route[before].Date.Time()).Minutes()
+	if elapsed <= 0 {
+		return 0
+	}
+	return (route[after].Altitude - route[before].Altitude) / elapsed
+}
+
+// Internal function that returns the elevation of the airport each report is nearest to.
+// Reports up to the highest one use the departure airport, and later ones the arrival airport.
+// Airports are estimated from the lowest report of the leading and trailing runs on the ground.
+func routeElevations(route []PositionReport, o PhaseOptions) []float64 {
+	elevations := make([]float64, len(route))
+	if o.Elevation != 0 {
+		for i := range elevations {
+			elevations[i] = o.Elevation
+		}
+		return elevations
+	}
+
+	highest := 0
+	for i, report := range route {
+		if report.Altitude > route[highest].Altitude {
+			highest = i
+		}
+	}
+	departure, departureOk := groundElevation(route, 0, 1, o.TaxiSpeed)
+	arrival, arrivalOk := groundElevation(route, len(route)-1, -1, o.TaxiSpeed)
+	if !departureOk {
+		departure = arrival
+	}
+	if !arrivalOk {
+		arrival = departure
+	}
+	for i := range elevations {
+		if i <= highest {
+			elevations[i] = departure
+		} else {
+			elevations[i] = arrival
+		}
+	}
+	return elevations
+}
+
+// Internal function that returns the lowest altitude of the reports below taxi speed,
+// walking the route from start in a direction until the first faster report
+func groundElevation(route []PositionReport, start int, direction int, taxiSpeed float64) (float64, bool) {
+	elevation, ok := 0.0, false
+	for i := start; i >= 0 && i < len(route) && route[i].GroundSpeed < taxiSpeed; i += direction {
+		if !ok || route[i].Altitude < elevation {
+			elevation, ok = route[i].Altitude, true
+		}
+	}
+	return elevation, ok
+}
+
+// Internal method that classifies a sample given its height above the airport, or above sea level if unknown
+func (o PhaseOptions) classify(height float64, speed float64, verticalSpeed float64, airborne bool) FlightPhase {
+	switch {
+	case speed < o.ParkedSpeed:
+		return PhaseParked
+	case speed < o.TaxiSpeed:
+		return PhaseTaxi
+	case height < runwayHeight:
+		if airborne {
+			return PhaseLanded
+		}
+		return PhaseTakeoff
+	case verticalSpeed >= o.LevelRate:
+		return PhaseClimb
+	case height < o.ApproachHeight:
+		return PhaseApproach
+	case verticalSpeed <= -o.LevelRate:
+		return PhaseDescent
+	}
+	return PhaseCruise
+}
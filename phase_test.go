@@ -0,0 +1,133 @@
+package golive_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+// Internal function that builds a route sampled every 15 seconds
+// from stretches flown at a steady ground speed and vertical speed
+func syntheticRoute(elevation float64, stretches ...[3]float64) []golive.PositionReport {
+	start := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	var route []golive.PositionReport
+	altitude := elevation
+	for _, stretch := range stretches {
+		samples, speed, rate := int(stretch[0]*4), stretch[1], stretch[2]
+		for i := 0; i < samples; i++ {
+			route = append(route, golive.PositionReport{
+				Altitude:    altitude,
+				GroundSpeed: speed,
+				Date:        golive.Time(start.Add(time.Duration(len(route)) * 15 * time.Second)),
+			})
+			altitude += rate / 4
+		}
+	}
+	return route
+}
+
+func phases(segments []golive.PhaseSegment) []golive.FlightPhase {
+	result := []golive.FlightPhase{}
+	for _, segment := range segments {
+		result = append(result, segment.Phase)
+	}
+	return result
+}
+
+func TestClassifyRoute(t *testing.T) {
+	// Minutes, ground speed and vertical speed of every stretch
+	route := syntheticRoute(100,
+		[3]float64{2, 0, 0},
+		[3]float64{4, 15, 0},
+		[3]float64{0.75, 120, 0},
+		[3]float64{15, 300, 2000},
+		[3]float64{5, 450, 0},
+		// Turbulence shorter than MinDuration does not end the cruise
+		[3]float64{0.25, 450, 800},
+		[3]float64{0.25, 450, -800},
+		[3]float64{5, 450, 0},
+		[3]float64{18, 280, -1500},
+		[3]float64{4.25, 150, -700},
+		[3]float64{0.75, 90, 0},
+		[3]float64{3, 15, 0},
+		[3]float64{1, 0, 0},
+	)
+	expected := []golive.FlightPhase{
+		golive.PhaseParked, golive.PhaseTaxi, golive.PhaseTakeoff, golive.PhaseClimb, golive.PhaseCruise,
+		golive.PhaseDescent, golive.PhaseApproach, golive.PhaseLanded, golive.PhaseTaxi, golive.PhaseParked,
+	}
+	segments := golive.ClassifyRoute(route, nil)
+	if actual := phases(segments); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected phases %v, got %v", expected, actual)
+	}
+
+	count := 0
+	for i, segment := range segments {
+		count += len(segment.Reports)
+		if !segment.Start.Equal(segment.Reports[0].Date.Time()) {
+			t.Errorf("%s starts at %v, its first report is at %v", segment.Phase, segment.Start, segment.Reports[0].Date.Time())
+		}
+		if i > 0 && !segments[i-1].End.Equal(segment.Start) {
+			t.Errorf("%s ends at %v, %s starts at %v", segments[i-1].Phase, segments[i-1].End, segment.Phase, segment.Start)
+		}
+	}
+	if count != len(route) {
+		t.Errorf("segments hold %d reports, the route has %d", count, len(route))
+	}
+	if cruise := segments[4].Duration(); cruise < 10*time.Minute || cruise > 11*time.Minute {
+		t.Errorf("expected a cruise of about 10 minutes, got %v", cruise)
+	}
+
+	// Without hysteresis the turbulence shows up
+	unfiltered := golive.ClassifyRoute(route, &golive.PhaseOptions{MinDuration: time.Nanosecond})
+	if len(unfiltered) <= len(segments) {
+		t.Errorf("expected more segments without hysteresis, got %v", phases(unfiltered))
+	}
+
+	// The fixture route climbs out of KLAX
+	fixture := golivetest.DefaultFixtures().Routes[golivetest.DeltaFlightId]
+	expected = []golive.FlightPhase{golive.PhaseParked, golive.PhaseTaxi, golive.PhaseTakeoff, golive.PhaseClimb}
+	if actual := phases(golive.ClassifyRoute(fixture, nil)); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected phases %v, got %v", expected, actual)
+	}
+
+	if segments := golive.ClassifyRoute(nil, nil); segments != nil {
+		t.Errorf("expected no segments, got %v", segments)
+	}
+}
+
+func TestClassifyFlight(t *testing.T) {
+	flights := golivetest.DefaultFixtures().Flights[golivetest.ExpertSessionId]
+	expected := map[string]golive.FlightPhase{
+		golivetest.DeltaFlightId:     golive.PhaseClimb,
+		golivetest.BritishFlightId:   golive.PhaseApproach,
+		golivetest.SouthwestFlightId: golive.PhaseTaxi,
+	}
+	for _, flight := range flights {
+		if phase := golive.ClassifyFlight(flight, nil); phase != expected[flight.Id] {
+			t.Errorf("%s: expected %s, got %s", flight.Callsign, expected[flight.Id], phase)
+		}
+	}
+
+	roll := golive.Flight{Altitude: 128, Speed: 110}
+	if phase := golive.ClassifyFlight(roll, &golive.PhaseOptions{Elevation: 125}); phase != golive.PhaseTakeoff {
+		t.Errorf("expected a takeoff roll, got %s", phase)
+	}
+	cruise := golive.Flight{Altitude: 36000, Speed: 480, VerticalSpeed: -40}
+	if phase := golive.ClassifyFlight(cruise, nil); phase != golive.PhaseCruise {
+		t.Errorf("expected cruise, got %s", phase)
+	}
+	descent := golive.Flight{Altitude: 21000, Speed: 400, VerticalSpeed: -2200}
+	if phase := golive.ClassifyFlight(descent, nil); phase != golive.PhaseDescent {
+		t.Errorf("expected descent, got %s", phase)
+	}
+
+	b, err := json.Marshal(map[string]golive.FlightPhase{"phase": golive.PhaseApproach})
+	if err != nil || string(b) != `{"phase":"Approach"}` {
+		t.Errorf("unexpected encoding %s, %v", b, err)
+	}
+}
@@ -0,0 +1,62 @@
+package golive
+
+import "github.com/sqeezelemon/golive/geo"
+
+// Position returns the flight's position.
+func (f Flight) Position() geo.Point {
+	return geo.Point{Latitude: f.Latitude, Longitude: f.Longitude}
+}
+
+// DistanceTo returns the great-circle distance from the flight to l in nautical miles.
+func (f Flight) DistanceTo(l Location) float64 {
+	return geo.Distance(f.Position(), l.Point())
+}
+
+// BearingTo returns the true course from the flight to l.
+func (f Flight) BearingTo(l Location) float64 {
+	return geo.InitialBearing(f.Position(), l.Point())
+}
+
+// Position returns the reported position.
+func (p PositionReport) Position() geo.Point {
+	return geo.Point{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+// Point returns the location without its altitude.
+func (l Location) Point() geo.Point {
+	return geo.Point{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+// Position returns the facility's position.
+func (f ActiveAtcFacility) Position() geo.Point {
+	return geo.Point{Latitude: f.Latitude, Longitude: f.Longitude}
+}
+
+// Position returns the facility's position.
+func (f AtcFacility) Position() geo.Point {
+	return geo.Point{Latitude: f.Latitude, Longitude: f.Longitude}
+}
+
+// Position returns the centre of the NOTAM's area.
+func (n Notam) Position() geo.Point {
+	return geo.Point{Latitude: n.Latitude, Longitude: n.Longitude}
+}
+
+// TotalDistance returns the great-circle length of the flight plan in nautical miles,
+// following its items, procedures included, in order.
+func (fp FlightPlan) TotalDistance() float64 {
+	var total float64
+	for _, leg := range fp.Legs() {
+		total += leg.Distance
+	}
+	return total
+}
+
+// RouteDistance returns the length of a flown route in nautical miles.
+func RouteDistance(route []PositionReport) float64 {
+	var total float64
+	for i := 1; i < len(route); i++ {
+		total += geo.Distance(route[i-1].Position(), route[i].Position())
+	}
+	return total
+}
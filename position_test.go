@@ -0,0 +1,32 @@
+package golive_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func TestPositions(t *testing.T) {
+	fixtures := golivetest.DefaultFixtures()
+	plan := fixtures.FlightPlans[golivetest.DeltaFlightId]
+	// KLAX to KSFO direct is 293 nm, the planned route is somewhat longer
+	if distance := plan.TotalDistance(); distance < 330 || distance > 380 {
+		t.Errorf("unexpected flight plan distance %.1f", distance)
+	}
+
+	flight := fixtures.Flights[golivetest.ExpertSessionId][0]
+	ksfo := plan.FlightPlanItems[len(plan.FlightPlanItems)-1].Location
+	if d := flight.DistanceTo(ksfo); math.Abs(d-269) > 2 {
+		t.Errorf("expected about 269 nm to KSFO, got %.1f", d)
+	}
+	if b := flight.BearingTo(ksfo); b < 300 || b > 330 {
+		t.Errorf("expected KSFO to the north-west, got %.1f", b)
+	}
+
+	route := fixtures.Routes[golivetest.DeltaFlightId]
+	if d := golive.RouteDistance(route); d < 30 || d > 40 {
+		t.Errorf("unexpected route distance %.1f", d)
+	}
+}
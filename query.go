@@ -0,0 +1,280 @@
+package golive
+
+import (
+	"math"
+	"sort"
+
+	"github.com/sqeezelemon/golive/geo"
+)
+
+// FlightQuery filters a set of flights, such as the result of GetFlights or Watcher.Flights.
+// Every method returns a new query, so a query can be refined in several ways.
+//
+//	nearby := golive.QueryFlights(flights).Within(egll, 50).AltitudeBetween(0, 10000).All()
+type FlightQuery struct {
+	flights []Flight
+	index   *FlightIndex
+	// Area the flights must be in, used to pick candidates from the index
+	box     *geo.BoundingBox
+	filters []func(Flight) bool
+}
+
+// QueryFlights starts a query over flights.
+func QueryFlights(flights []Flight) FlightQuery {
+	return FlightQuery{flights: flights}
+}
+
+// Internal method that returns a copy of the query with another filter
+func (q FlightQuery) with(box *geo.BoundingBox, filter func(Flight) bool) FlightQuery {
+	q.filters = append(q.filters[:len(q.filters):len(q.filters)], filter)
+	if box != nil && q.box == nil {
+		q.box = box
+	}
+	return q
+}
+
+// Within keeps flights within radius nautical miles of center.
+func (q FlightQuery) Within(center geo.Point, radius float64) FlightQuery {
+	box := geo.BoundingBoxAround(center, radius)
+	return q.with(&box, func(f Flight) bool {
+		return geo.Distance(center, f.Position()) <= radius
+	})
+}
+
+// InBox keeps flights inside box.
+func (q FlightQuery) InBox(box geo.BoundingBox) FlightQuery {
+	return q.with(&box, func(f Flight) bool {
+		return box.Contains(f.Position())
+	})
+}
+
+// InPolygon keeps flights inside polygon.
+func (q FlightQuery) InPolygon(polygon geo.Polygon) FlightQuery {
+	box := polygon.BoundingBox()
+	return q.with(&box, func(f Flight) bool {
+		return polygon.Contains(f.Position())
+	})
+}
+
+// AltitudeBetween keeps flights between two altitudes in feet, inclusive.
+func (q FlightQuery) AltitudeBetween(min float64, max float64) FlightQuery {
+	return q.with(nil, func(f Flight) bool {
+		return f.Altitude >= min && f.Altitude <= max
+	})
+}
+
+// Where keeps flights for which keep returns true.
+func (q FlightQuery) Where(keep func(Flight) bool) FlightQuery {
+	return q.with(nil, keep)
+}
+
+// All returns the flights matching the query, in their original order.
+func (q FlightQuery) All() []Flight {
+	var result []Flight
+	for _, flight := range q.candidates() {
+		if q.matches(flight) {
+			result = append(result, flight)
+		}
+	}
+	return result
+}
+
+// Count returns the number of flights matching the query.
+func (q FlightQuery) Count() int {
+	return len(q.All())
+}
+
+// Nearest returns up to n flights matching the query, closest to p first.
+func (q FlightQuery) Nearest(p geo.Point, n int) []Flight {
+	if n <= 0 {
+		return nil
+	}
+	if q.index != nil && q.box == nil {
+		// Find a radius holding enough flights, then search exactly within it
+		if radius, ok := q.index.radiusHolding(p, n, q.matches); ok {
+			return q.Within(p, radius).Nearest(p, n)
+		}
+	}
+
+	matches := q.All()
+	distances := make([]float64, len(matches))
+	for i, flight := range matches {
+		distances[i] = geo.Distance(p, flight.Position())
+	}
+	sort.Sort(byDistance{matches, distances})
+	if len(matches) > n {
+		matches = matches[:n]
+	}
+	return matches
+}
+
+func (q FlightQuery) candidates() []Flight {
+	if q.index != nil {
+		if q.box != nil {
+			return q.index.inBox(*q.box)
+		}
+		return q.index.flights
+	}
+	return q.flights
+}
+
+func (q FlightQuery) matches(f Flight) bool {
+	for _, filter := range q.filters {
+		if !filter(f) {
+			return false
+		}
+	}
+	return true
+}
+
+type byDistance struct {
+	flights   []Flight
+	distances []float64
+}
+
+func (s byDistance) Len() int           { return len(s.flights) }
+func (s byDistance) Less(i, j int) bool { return s.distances[i] < s.distances[j] }
+func (s byDistance) Swap(i, j int) {
+	s.flights[i], s.flights[j] = s.flights[j], s.flights[i]
+	s.distances[i], s.distances[j] = s.distances[j], s.distances[i]
+}
+
+////// INDEX
+
+// Default size of FlightIndex cells in degrees
+const defaultCellSize = 1.0
+
+// FlightIndex buckets flights into a latitude and longitude grid,
+// so that spatial queries over a large session only look at nearby flights.
+// It is immutable and safe for concurrent use.
+type FlightIndex struct {
+	flights  []Flight
+	cellSize float64
+	cells    map[gridCell][]int
+}
+
+type gridCell struct {
+	row    int
+	column int
+}
+
+// NewFlightIndex indexes flights using cells of cellSize degrees, 1 degree if zero.
+func NewFlightIndex(flights []Flight, cellSize float64) *FlightIndex {
+	if cellSize <= 0 {
+		cellSize = defaultCellSize
+	}
+	index := &FlightIndex{
+		flights:  flights,
+		cellSize: cellSize,
+		cells:    map[gridCell][]int{},
+	}
+	for i, flight := range flights {
+		cell := index.cell(flight.Position())
+		index.cells[cell] = append(index.cells[cell], i)
+	}
+	return index
+}
+
+// Query starts a query over the indexed flights.
+func (index *FlightIndex) Query() FlightQuery {
+	return FlightQuery{flights: index.flights, index: index}
+}
+
+func (index *FlightIndex) cell(p geo.Point) gridCell {
+	return gridCell{
+		row:    int(math.Floor(p.Latitude / index.cellSize)),
+		column: int(math.Floor(geo.NormalizeLongitude(p.Longitude) / index.cellSize)),
+	}
+}
+
+// Internal method that returns the flights in every cell overlapping box, in their original order
+func (index *FlightIndex) inBox(box geo.BoundingBox) []Flight {
+	var positions []int
+	if box.West > box.East {
+		// Split at the antimeridian
+		positions = index.positions(geo.BoundingBox{South: box.South, West: box.West, North: box.North, East: 180})
+		positions = append(positions, index.positions(geo.BoundingBox{South: box.South, West: -180, North: box.North, East: box.East})...)
+	} else {
+		positions = index.positions(box)
+	}
+	sort.Ints(positions)
+
+	flights := make([]Flight, len(positions))
+	for i, position := range positions {
+		flights[i] = index.flights[position]
+	}
+	return flights
+}
+
+// Internal method that returns the positions of the flights in every cell overlapping a box
+// that does not cross the antimeridian
+func (index *FlightIndex) positions(box geo.BoundingBox) []int {
+	low := index.cell(geo.Point{Latitude: box.South, Longitude: box.West})
+	high := index.cell(geo.Point{Latitude: box.North, Longitude: box.East})
+	if box.East >= 180 {
+		high.column = int(math.Ceil(180/index.cellSize)) - 1
+	}
+	var positions []int
+	for row := low.row; row <= high.row; row++ {
+		for column := low.column; column <= high.column; column++ {
+			positions = append(positions, index.cells[gridCell{row, column}]...)
+		}
+	}
+	return positions
+}
+
+// Internal method that searches rings of cells around p until n matching flights are found,
+// and returns the distance to the furthest of them. Rings wrap around the antimeridian.
+// It gives up once every flight was visited, or once it visited more cells than there are flights,
+// as a linear scan is then no slower.
+func (index *FlightIndex) radiusHolding(p geo.Point, n int, matches func(Flight) bool) (float64, bool) {
+	center := index.cell(p)
+	firstColumn := int(math.Floor(-180 / index.cellSize))
+	columns := int(math.Ceil(180/index.cellSize)) - firstColumn
+	firstRow := int(math.Floor(-90 / index.cellSize))
+	lastRow := int(math.Floor(90 / index.cellSize))
+
+	var distances []float64
+	visitedFlights, visitedCells := 0, 0
+	// Cells already searched, as wide rings wrap onto themselves
+	seen := map[gridCell]bool{}
+	visit := func(row int, column int) {
+		if row < firstRow || row > lastRow {
+			return
+		}
+		cell := gridCell{row, firstColumn + ((column-firstColumn)%columns+columns)%columns}
+		if seen[cell] {
+			return
+		}
+		seen[cell] = true
+		visitedCells++
+		for _, i := range index.cells[cell] {
+			visitedFlights++
+			if matches(index.flights[i]) {
+				distances = append(distances, geo.Distance(p, index.flights[i].Position()))
+			}
+		}
+	}
+
+	// Rings past this one only revisit cells
+	lastRing := columns + lastRow - firstRow
+	for ring := 0; ring <= lastRing && visitedFlights < len(index.flights) && visitedCells <= len(index.flights); ring++ {
+		if ring == 0 {
+			visit(center.row, center.column)
+		} else {
+			for column := center.column - ring; column <= center.column+ring; column++ {
+				visit(center.row-ring, column)
+				visit(center.row+ring, column)
+			}
+			for row := center.row - ring + 1; row < center.row+ring; row++ {
+				visit(row, center.column-ring)
+				visit(row, center.column+ring)
+			}
+		}
+		if len(distances) >= n {
+			sort.Float64s(distances)
+			return distances[n-1], true
+		}
+	}
+	return 0, false
+}
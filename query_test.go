@@ -0,0 +1,138 @@
+package golive_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/geo"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+// Flights scattered around the world, including both sides of the antimeridian
+func scatteredFlights(count int) []golive.Flight {
+	random := rand.New(rand.NewSource(1))
+	flights := make([]golive.Flight, count)
+	for i := range flights {
+		flights[i] = golive.Flight{
+			Id:        strconv.Itoa(i),
+			Latitude:  random.Float64()*160 - 80,
+			Longitude: random.Float64()*360 - 180,
+			Altitude:  random.Float64() * 40000,
+		}
+	}
+	return flights
+}
+
+func flightIds(flights []golive.Flight) []string {
+	ids := []string{}
+	for _, flight := range flights {
+		ids = append(ids, flight.Id)
+	}
+	return ids
+}
+
+func TestQueryFlights(t *testing.T) {
+	flights := golivetest.DefaultFixtures().Flights[golivetest.ExpertSessionId]
+	klax := geo.Point{Latitude: 33.9425, Longitude: -118.4081}
+
+	// The Delta flight has climbed out about 28 nm west of the airport
+	nearby := golive.QueryFlights(flights).Within(klax, 15).All()
+	if ids := flightIds(nearby); !reflect.DeepEqual(ids, []string{golivetest.BritishFlightId, golivetest.SouthwestFlightId}) {
+		t.Errorf("unexpected flights near KLAX %v", ids)
+	}
+
+	all := golive.QueryFlights(flights)
+	if low := all.AltitudeBetween(0, 20000).Count() + all.AltitudeBetween(20000.001, 60000).Count(); low != len(flights) {
+		t.Errorf("altitude bands hold %d flights, expected %d", low, len(flights))
+	}
+	if ids := flightIds(all.Nearest(klax, 2)); !reflect.DeepEqual(ids, []string{golivetest.SouthwestFlightId, golivetest.BritishFlightId}) {
+		t.Errorf("unexpected nearest flights %v", ids)
+	}
+	if none := all.Where(func(golive.Flight) bool { return false }).All(); len(none) != 0 {
+		t.Errorf("expected no flights, got %v", flightIds(none))
+	}
+}
+
+func TestFlightIndex(t *testing.T) {
+	flights := scatteredFlights(2000)
+	index := golive.NewFlightIndex(flights, 2)
+	plain := golive.QueryFlights(flights)
+
+	fiji := geo.Point{Latitude: -17.7, Longitude: 179.5}
+	pacific := geo.BoundingBox{South: -30, West: 170, North: 0, East: -170}
+	triangle := geo.Polygon{{Latitude: 40, Longitude: 175}, {Latitude: 60, Longitude: -160}, {Latitude: 35, Longitude: -170}}
+
+	tests := []struct {
+		name  string
+		query func(golive.FlightQuery) golive.FlightQuery
+	}{
+		{"within", func(q golive.FlightQuery) golive.FlightQuery { return q.Within(fiji, 600) }},
+		{"box", func(q golive.FlightQuery) golive.FlightQuery { return q.InBox(pacific) }},
+		{"polygon", func(q golive.FlightQuery) golive.FlightQuery { return q.InPolygon(triangle) }},
+		{"combined", func(q golive.FlightQuery) golive.FlightQuery {
+			return q.InBox(pacific).Within(fiji, 900).AltitudeBetween(10000, 30000)
+		}},
+	}
+	for _, test := range tests {
+		expected := test.query(plain).All()
+		actual := test.query(index.Query()).All()
+		if len(expected) == 0 {
+			t.Errorf("%s: query matched nothing, the test is ineffective", test.name)
+		}
+		if !reflect.DeepEqual(flightIds(expected), flightIds(actual)) {
+			t.Errorf("%s: index returned %v, expected %v", test.name, flightIds(actual), flightIds(expected))
+		}
+	}
+
+	for _, n := range []int{1, 5, 50} {
+		expected := plain.Nearest(fiji, n)
+		actual := index.Query().Nearest(fiji, n)
+		if len(actual) != n || !reflect.DeepEqual(flightIds(expected), flightIds(actual)) {
+			t.Errorf("nearest %d: index returned %v, expected %v", n, flightIds(actual), flightIds(expected))
+		}
+	}
+	high := func(q golive.FlightQuery) golive.FlightQuery { return q.AltitudeBetween(39000, 40000) }
+	if expected, actual := high(plain).Nearest(fiji, 3), high(index.Query()).Nearest(fiji, 3); !reflect.DeepEqual(flightIds(expected), flightIds(actual)) {
+		t.Errorf("filtered nearest: index returned %v, expected %v", flightIds(actual), flightIds(expected))
+	}
+	if all := index.Query().Nearest(fiji, 5000); len(all) != len(flights) {
+		t.Errorf("expected every flight, got %d", len(all))
+	}
+}
+
+func TestFlightIndexFewMatches(t *testing.T) {
+	fiji := geo.Point{Latitude: -17.7, Longitude: 179.5}
+	start := time.Now()
+
+	// Fewer flights than requested in small cells
+	few := scatteredFlights(2)
+	if nearest := golive.NewFlightIndex(few, 0.1).Query().Nearest(fiji, 5); len(nearest) != len(few) {
+		t.Errorf("expected every flight, got %v", flightIds(nearest))
+	}
+
+	// Filters matching fewer flights than requested
+	flights := scatteredFlights(2000)
+	high := func(q golive.FlightQuery) golive.FlightQuery { return q.AltitudeBetween(39990, 40000) }
+	expected := high(golive.QueryFlights(flights)).Nearest(fiji, 5)
+	actual := high(golive.NewFlightIndex(flights, 0.1).Query()).Nearest(fiji, 5)
+	if len(expected) >= 5 {
+		t.Errorf("filter matched %d flights, the test is ineffective", len(expected))
+	}
+	if !reflect.DeepEqual(flightIds(expected), flightIds(actual)) {
+		t.Errorf("index returned %v, expected %v", flightIds(actual), flightIds(expected))
+	}
+
+	// Flights just across the antimeridian are found by the ring search
+	across := []golive.Flight{{Id: "east", Latitude: -17.7, Longitude: -179.9}, {Id: "far", Latitude: 40, Longitude: 0}}
+	if nearest := golive.NewFlightIndex(across, 0.1).Query().Nearest(fiji, 1); !reflect.DeepEqual(flightIds(nearest), []string{"east"}) {
+		t.Errorf("unexpected nearest flight %v", flightIds(nearest))
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("searches took %v", elapsed)
+	}
+}
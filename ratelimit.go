@@ -0,0 +1,149 @@
+package golive
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned instead of waiting for the rate limiter
+// when the request context was marked with FailFast.
+var ErrRateLimited = errors.New("golive: client rate limit exceeded")
+
+// RateLimiter is a token bucket limiting how often requests are sent.
+// It is safe for concurrent use and can be shared by several clients using the same API key.
+type RateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimiterStats
+}
+
+// RateLimiterStats describes how a RateLimiter has affected requests so far.
+type RateLimiterStats struct {
+	// Requests is the number of requests that passed the limiter.
+	Requests int64
+	// Delayed is the number of requests that had to wait for a token.
+	Delayed int64
+	// Rejected is the number of requests that failed fast or whose context ended while waiting.
+	Rejected int64
+	// TotalWait is the time spent waiting by all requests.
+	TotalWait time.Duration
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerSecond on average
+// with bursts of up to burst requests. The bucket starts full.
+// A requestsPerSecond of zero or less never refills it, so once burst requests are sent
+// every other one fails with ErrRateLimited.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	if requestsPerSecond < 0 {
+		requestsPerSecond = 0
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond with bursts of up to burst requests.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return WithRateLimiter(NewRateLimiter(requestsPerSecond, burst))
+}
+
+// WithRateLimiter makes the client use an existing, possibly shared, limiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// RateLimiterStats returns the counters of the client's rate limiter,
+// or zero values if the client is not rate limited.
+func (c *Client) RateLimiterStats() RateLimiterStats {
+	if c.limiter == nil {
+		return RateLimiterStats{}
+	}
+	return c.limiter.Stats()
+}
+
+type failFastKey struct{}
+
+// FailFast returns a context that makes rate limited requests
+// return ErrRateLimited immediately instead of waiting for a token.
+func FailFast(ctx context.Context) context.Context {
+	return context.WithValue(ctx, failFastKey{}, true)
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+// Contexts created by FailFast never wait, and neither do those
+// whose deadline expires before a token would become available.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mutex.Lock()
+	now := time.Now()
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		l.stats.Requests++
+		l.mutex.Unlock()
+		return nil
+	}
+
+	failFast, _ := ctx.Value(failFastKey{}).(bool)
+	if failFast || l.rate <= 0 {
+		l.stats.Rejected++
+		l.mutex.Unlock()
+		return ErrRateLimited
+	}
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(wait)) {
+		l.stats.Rejected++
+		l.mutex.Unlock()
+		return ErrRateLimited
+	}
+	// Reserve the token now so concurrent callers queue up behind us
+	l.tokens--
+	l.mutex.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		l.mutex.Lock()
+		l.stats.Requests++
+		l.stats.Delayed++
+		l.stats.TotalWait += wait
+		l.mutex.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mutex.Lock()
+		l.tokens++
+		l.stats.Rejected++
+		l.stats.TotalWait += time.Since(now)
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the limiter's counters.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.stats
+}
+
+// Internal method that adds the tokens accumulated since the last call, must hold the mutex
+func (l *RateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
@@ -0,0 +1,111 @@
+package golive
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := NewRateLimiter(1, 3)
+	ctx := FailFast(context.Background())
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+	}
+	if err := limiter.Wait(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	stats := limiter.Stats()
+	if stats.Requests != 3 || stats.Rejected != 1 || stats.Delayed != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	limiter := NewRateLimiter(100, 1)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// One request passes immediately, the other nine wait 10ms each in turn
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("10 requests at 100/s took only %v", elapsed)
+	}
+	stats := limiter.Stats()
+	if stats.Requests != 10 || stats.Delayed != 9 || stats.TotalWait <= 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiterDeadline(t *testing.T) {
+	limiter := NewRateLimiter(0.1, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited for a deadline before the next token, got %v", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if err := limiter.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	// The cancelled waiter gives its token back
+	if limiter.tokens < -0.01 {
+		t.Errorf("token not returned, have %v", limiter.tokens)
+	}
+}
+
+func TestRateLimiterZeroRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		limiter := NewRateLimiter(rate, 1)
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("rate %v: the first request should use the burst, got %v", rate, err)
+		}
+		time.Sleep(time.Millisecond)
+		if err := limiter.Wait(context.Background()); !errors.Is(err, ErrRateLimited) {
+			t.Errorf("rate %v: expected ErrRateLimited, got %v", rate, err)
+		}
+		if limiter.tokens != 0 {
+			t.Errorf("rate %v: expected an empty bucket, have %v", rate, limiter.tokens)
+		}
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	var calls int32
+	c := fakeClient(t, flakyHandler(0, 0, &calls), WithRateLimit(1, 1), WithRetryPolicy(fastRetries))
+
+	if _, err := c.GetFlights("s1"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.GetFlightsCtx(FailFast(context.Background()), "s1")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the limited request not to be sent, got %d calls", calls)
+	}
+	if stats := c.RateLimiterStats(); stats.Requests != 1 || stats.Rejected != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats := NewClient("key", nil).RateLimiterStats(); stats != (RateLimiterStats{}) {
+		t.Errorf("expected empty stats without a limiter, got %+v", stats)
+	}
+}
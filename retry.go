@@ -0,0 +1,159 @@
+package golive
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRetryStatusCodes are the HTTP status codes retried when RetryPolicy.RetryStatusCodes is nil.
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy is a reasonable policy for pollers:
+// three attempts with exponential backoff from 500ms to 10s and 20% jitter.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	RetryApiErrors: []ApiError{ErrEndpoint},
+}
+
+// RetryPolicy controls how failed requests are retried.
+// The zero value disables retries, which is the default for a new Client.
+//
+// Transport errors and the configured status codes and ApiErrors are retried,
+// unless the caller's context is done or its deadline would pass during the delay,
+// in which case the last error is returned straight away. Only GET requests are retried
+// unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, 100ms if zero.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, no cap if zero.
+	// A response asking to retry after longer than MaxBackoff is returned without retrying.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every attempt, 2 if less than 1.
+	Multiplier float64
+	// Jitter randomises every delay by up to this fraction of it, between 0 and 1.
+	Jitter float64
+	// RetryStatusCodes lists retryable HTTP status codes, DefaultRetryStatusCodes if nil.
+	RetryStatusCodes []int
+	// RetryApiErrors lists retryable Live API error codes.
+	RetryApiErrors []ApiError
+	// RetryNonIdempotent enables retries for POST requests such as GetUserStats.
+	RetryNonIdempotent bool
+	// IgnoreRetryAfter disables waiting for the duration in a Retry-After header,
+	// and retrying responses whose Retry-After exceeds MaxBackoff with the usual backoff.
+	IgnoreRetryAfter bool
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// Internal method that reports whether a failed attempt should be retried
+func (p RetryPolicy) retryable(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		return false
+	}
+	var requestErr *RequestError
+	if !errors.As(err, &requestErr) {
+		// Transport error
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		codes := p.RetryStatusCodes
+		if codes == nil {
+			codes = DefaultRetryStatusCodes
+		}
+		for _, code := range codes {
+			if httpErr.StatusCode == code {
+				return true
+			}
+		}
+		return false
+	}
+
+	var apiErr ApiError
+	if errors.As(err, &apiErr) {
+		for _, code := range p.RetryApiErrors {
+			if apiErr == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	jitterMutex sync.Mutex
+	jitterRand  = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Internal method that calculates the delay after a failed attempt (counting from 1).
+// It returns false if the response asks to wait for longer than MaxBackoff.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(backoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitterMutex.Lock()
+		delay *= 1 + p.Jitter*(2*jitterRand.Float64()-1)
+		jitterMutex.Unlock()
+	}
+
+	var httpErr *HTTPError
+	if !p.IgnoreRetryAfter && errors.As(err, &httpErr) {
+		after := retryAfter(httpErr.Header, time.Now())
+		if p.MaxBackoff > 0 && after > p.MaxBackoff {
+			return 0, false
+		}
+		if float64(after) > delay {
+			return after, true
+		}
+	}
+	return time.Duration(delay), true
+}
+
+// Internal function that parses a Retry-After header in either seconds or HTTP date form
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
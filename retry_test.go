@@ -0,0 +1,188 @@
+package golive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastRetries = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	RetryApiErrors: []ApiError{ErrEndpoint},
+}
+
+// flakyHandler fails the first n requests with the given status code
+func flakyHandler(n int32, status int, calls *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		if atomic.AddInt32(calls, 1) <= n {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"errorCode":0,"result":[{"flightId":"f1"}]}`))
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	var calls int32
+	c := fakeClient(t, flakyHandler(2, http.StatusServiceUnavailable, &calls), WithRetryPolicy(fastRetries))
+
+	flights, err := c.GetFlights("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || len(flights) != 1 {
+		t.Errorf("expected success on 3rd attempt, got %d calls and %v", calls, flights)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int32
+	c := fakeClient(t, flakyHandler(10, http.StatusBadGateway, &calls), WithRetryPolicy(fastRetries))
+
+	_, err := c.GetFlights("s1")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected 502 HTTPError, got %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 attempts, got %d", calls)
+	}
+}
+
+func TestRetrySkipsNonRetryable(t *testing.T) {
+	var calls int32
+	c := fakeClient(t, flakyHandler(10, http.StatusNotFound, &calls), WithRetryPolicy(fastRetries))
+
+	if _, err := c.GetFlights("s1"); err == nil {
+		t.Error("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryPost(t *testing.T) {
+	var calls int32
+	c := fakeClient(t, flakyHandler(1, http.StatusServiceUnavailable, &calls), WithRetryPolicy(fastRetries))
+	if _, err := c.GetUserStats([]string{"u1"}, nil, nil); err == nil {
+		t.Error("expected POST not to be retried")
+	}
+
+	calls = 0
+	policy := fastRetries
+	policy.RetryNonIdempotent = true
+	c = fakeClient(t, flakyHandler(1, http.StatusServiceUnavailable, &calls), WithRetryPolicy(policy))
+	if _, err := c.GetUserStats([]string{"u1"}, nil, nil); err != nil {
+		t.Error(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryApiError(t *testing.T) {
+	var calls int32
+	c := fakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Write([]byte(`{"errorCode":3,"result":null}`))
+			return
+		}
+		w.Write([]byte(`{"errorCode":0,"result":[]}`))
+	}, WithRetryPolicy(fastRetries))
+
+	if _, err := c.GetWorldStatus("s1"); err != nil {
+		t.Error(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 3}
+	for attempt, expected := range []time.Duration{time.Second, 3 * time.Second, 5 * time.Second} {
+		if delay, ok := policy.delay(attempt+1, nil); !ok || delay != expected {
+			t.Errorf("attempt %d: expected %v, got %v", attempt+1, expected, delay)
+		}
+	}
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if delay, _ := policy.delay(1, nil); delay < 500*time.Millisecond || delay > 1500*time.Millisecond {
+			t.Fatalf("jittered delay %v out of range", delay)
+		}
+	}
+
+	retryAfter := func(seconds string) error {
+		return &RequestError{"GET", "sessions", &HTTPError{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{seconds}},
+		}}
+	}
+	if delay, ok := policy.delay(1, retryAfter("3")); !ok || delay != 3*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %v, %v", delay, ok)
+	}
+	if _, ok := policy.delay(1, retryAfter("30")); ok {
+		t.Error("expected a Retry-After over MaxBackoff not to be retried")
+	}
+	uncapped := policy
+	uncapped.MaxBackoff = 0
+	if delay, ok := uncapped.delay(1, retryAfter("30")); !ok || delay != 30*time.Second {
+		t.Errorf("expected Retry-After to be honoured without MaxBackoff, got %v, %v", delay, ok)
+	}
+	policy.IgnoreRetryAfter = true
+	if delay, ok := policy.delay(1, retryAfter("30")); !ok || delay > 1500*time.Millisecond {
+		t.Errorf("expected Retry-After to be ignored, got %v, %v", delay, ok)
+	}
+}
+
+func TestRetryAfterOverMaxBackoff(t *testing.T) {
+	var calls int32
+	c := fakeClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, WithRetryPolicy(DefaultRetryPolicy))
+
+	start := time.Now()
+	_, err := c.GetFlights("s1")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected 429 HTTPError, got %v", err)
+	}
+	if calls != 1 || time.Since(start) > time.Second {
+		t.Errorf("expected one attempt without waiting, got %d in %v", calls, time.Since(start))
+	}
+}
+
+func TestRetrySkipsWaitPastDeadline(t *testing.T) {
+	var calls int32
+	policy := fastRetries
+	policy.InitialBackoff, policy.MaxBackoff = time.Second, time.Second
+	c := fakeClient(t, flakyHandler(10, http.StatusServiceUnavailable, &calls), WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := c.GetFlightsCtx(ctx, "s1")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the 503 HTTPError rather than waiting for the deadline, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	header := http.Header{"Retry-After": []string{now.Add(90 * time.Second).Format(http.TimeFormat)}}
+	if after := retryAfter(header, now); after != 90*time.Second {
+		t.Errorf("expected 90s, got %v", after)
+	}
+}
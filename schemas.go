@@ -1,62 +1,74 @@
 package golive
 
-import "time"
-
 type apiResponse[T any] struct {
 	ErrorCode int `json:"errorCode"`
 	Result    T   `json:"result"`
 }
 
+// envelope is implemented by every apiResponse so the client can inspect the error code
+type envelope interface {
+	errorCode() int
+	reset()
+}
+
+func (r *apiResponse[T]) errorCode() int {
+	return r.ErrorCode
+}
+
+func (r *apiResponse[T]) reset() {
+	*r = apiResponse[T]{}
+}
+
 type Session struct {
-	MaxUsers  int    `json:"maxUsers"`
-	Id        string `json:"id"`
-	Name      string `json:"name"`
-	UserCount int    `json:"UserCount"`
-	Type      int    `json:"type"`
+	MaxUsers  int         `json:"maxUsers"`
+	Id        string      `json:"id"`
+	Name      string      `json:"name"`
+	UserCount int         `json:"UserCount"`
+	Type      SessionType `json:"type"`
 }
 
 type Flight struct {
-	Username            string       `json:"username"`
-	Callsign            string       `json:"callsign"`
-	Latitude            float64      `json:"latitude"`
-	Longitude           float64      `json:"longitude"`
-	Altitude            float64      `json:"altitude"`
-	Speed               float64      `json:"speed"`
-	VerticalSpeed       float64      `json:"verticalSpeed"`
-	Track               float64      `json:"track"`
-	LastReport          TimeWithoutT `json:"lastReport"`
-	Id                  string       `json:"flightId"`
-	UserId              string       `json:"userId"`
-	AircraftId          string       `json:"aircraftId"`
-	LiveryId            string       `json:"liveryId"`
-	Heading             float64      `json:"heading"`
-	VirtualOrganization string       `json:"virtualOrganization"`
+	Username            string  `json:"username"`
+	Callsign            string  `json:"callsign"`
+	Latitude            float64 `json:"latitude"`
+	Longitude           float64 `json:"longitude"`
+	Altitude            float64 `json:"altitude"`
+	Speed               float64 `json:"speed"`
+	VerticalSpeed       float64 `json:"verticalSpeed"`
+	Track               float64 `json:"track"`
+	LastReport          Time    `json:"lastReport"`
+	Id                  string  `json:"flightId"`
+	UserId              string  `json:"userId"`
+	AircraftId          string  `json:"aircraftId"`
+	LiveryId            string  `json:"liveryId"`
+	Heading             float64 `json:"heading"`
+	VirtualOrganization string  `json:"virtualOrganization"`
 }
 
 type PositionReport struct {
-	Latitude    float64   `json:"latitude"`
-	Longitude   float64   `json:"longitude"`
-	Altitude    float64   `json:"altitude"`
-	Track       float64   `json:"track"`
-	GroundSpeed float64   `json:"groundSpeed"`
-	Date        time.Time `json:"date"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Altitude    float64 `json:"altitude"`
+	Track       float64 `json:"track"`
+	GroundSpeed float64 `json:"groundSpeed"`
+	Date        Time    `json:"date"`
 }
 
 type FlightPlan struct {
 	Id              string           `json:"flightPlanId"`
 	FlightId        string           `json:"flightId"`
 	Waypoints       []string         `json:"waypoints"`
-	LastUpdate      TimeWithoutT     `json:"lastUpdate"`
+	LastUpdate      Time             `json:"lastUpdate"`
 	FlightPlanItems []FlightPlanItem `json:"flightPlanItems"`
 }
 
 type FlightPlanItem struct {
-	Name       string           `json:"name"`
-	Type       int              `json:"type"`
-	Children   []FlightPlanItem `json:"children"`
-	Identifier string           `json:"identifier"`
-	Altitude   int              `json:"altitude"`
-	Location   Location         `json:"location"`
+	Name       string             `json:"name"`
+	Type       FlightPlanItemType `json:"type"`
+	Children   []FlightPlanItem   `json:"children"`
+	Identifier string             `json:"identifier"`
+	Altitude   int                `json:"altitude"`
+	Location   Location           `json:"location"`
 }
 
 type Location struct {
@@ -71,10 +83,10 @@ type ActiveAtcFacility struct {
 	Username            string       `json:"username"`
 	VirtualOrganization string       `json:"virtualOrganization"`
 	AirportName         string       `json:"airportName"`
-	Type                int          `json:"type"`
+	Type                FacilityType `json:"type"`
 	Latitude            float64      `json:"latitude"`
 	Longitude           float64      `json:"longitude"`
-	StartTime           TimeWithoutT `json:"startTime"`
+	StartTime           Time         `json:"startTime"`
 }
 
 type UserStats struct {
@@ -88,7 +100,7 @@ type UserStats struct {
 	Grade                 int            `json:"grade"`
 	Hash                  string         `json:"hash"`
 	ViolationCountByLevel ViolationCount `json:"violationCountByLevel"`
-	Roles                 []int          `json:"roles"`
+	Roles                 []Role         `json:"roles"`
 	UserId                string         `json:"userId"`
 	VirtualOrganization   string         `json:"virtualOrganization"`
 	DiscourseUsername     string         `json:"discourseUsername"`
@@ -101,12 +113,12 @@ type UserGrade struct {
 	TotalXP                 int                `json:"totalXP"`
 	AtcOperations           int                `json:"atcOperations"`
 	AtcRank                 int                `json:"atcRank"`
-	LastLevel1ViolationDate time.Time          `json:"lastLevel1ViolationDate"`
-	LastLevel2ViolationDate time.Time          `json:"lastLevel2ViolationDate"`
-	LastLevel3ViolationDate time.Time          `json:"lastLevel3ViolationDate"`
-	LastReportViolationDate time.Time          `json:"lastReportViolationDate"`
+	LastLevel1ViolationDate Time               `json:"lastLevel1ViolationDate"`
+	LastLevel2ViolationDate Time               `json:"lastLevel2ViolationDate"`
+	LastLevel3ViolationDate Time               `json:"lastLevel3ViolationDate"`
+	LastReportViolationDate Time               `json:"lastReportViolationDate"`
 	ViolationCountByLevel   ViolationCount     `json:"violationCountByLevel"`
-	Roles                   []int              `json:"roles"`
+	Roles                   []Role             `json:"roles"`
 	UserId                  string             `json:"userId"`
 	VirtualOrganization     string             `json:"virtualOrganization"`
 	DiscourseUsername       string             `json:"discourseUsername"`
@@ -163,12 +175,12 @@ type AirportStatus struct {
 }
 
 type Track struct {
-	Name       string    `json:"name"`
-	Path       []string  `json:"path"`
-	EastLevels []int     `json:"eastLevels"`
-	WestLevels []int     `json:"westLevels"`
-	Type       string    `json:"type"`
-	LastSeen   time.Time `json:"lastSeen"`
+	Name       string   `json:"name"`
+	Path       []string `json:"path"`
+	EastLevels []int    `json:"eastLevels"`
+	WestLevels []int    `json:"westLevels"`
+	Type       string   `json:"type"`
+	LastSeen   Time     `json:"lastSeen"`
 }
 
 type LogbookPage[T any] struct {
@@ -183,7 +195,7 @@ type LogbookPage[T any] struct {
 type FlightLogbookPage LogbookPage[LoggedFlight]
 type LoggedFlight struct {
 	Id                 string  `json:"id"`
-	Created            string  `json:"created"`
+	Created            Time    `json:"created"`
 	UserId             string  `json:"userId"`
 	AircraftId         string  `json:"aircraftId"`
 	LiveryId           string  `json:"liveryId"`
@@ -203,35 +215,35 @@ type LoggedAtcSession struct {
 	Id             string      `json:"id"`
 	SessionGroupId string      `json:"atcSessionGroupId"`
 	Facility       AtcFacility `json:"facility"`
-	Created        string      `json:"created"`
-	Updated        string      `json:"updated"`
+	Created        Time        `json:"created"`
+	Updated        Time        `json:"updated"`
 	Operations     int         `json:"operations"`
 	TotalTime      float64     `json:"totalTime"`
 }
 
 type AtcFacility struct {
-	Id        string  `json:"id"`
-	Icao      string  `json:"airportIcao"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Type      int     `json:"frequencyType"`
+	Id        string       `json:"id"`
+	Icao      string       `json:"airportIcao"`
+	Latitude  float64      `json:"latitude"`
+	Longitude float64      `json:"longitude"`
+	Type      FacilityType `json:"frequencyType"`
 }
 
 type Notam struct {
-	Id        string  `json:"id"`
-	Title     string  `json:"title"`
-	Author    string  `json:"author"`
-	Type      int     `json:"type"`
-	SessionId string  `json:"sessionId"`
-	Radius    int     `json:"radius"`
-	Message   string  `json:"message"`
-	Longitude float64 `json:"longitude"`
-	Latitude  float64 `json:"latitude"`
-	Icao      string  `json:"icao"`
-	Floor     int     `json:"floor"`
-	Ceiling   int     `json:"ceiling"`
-	StartTime string  `json:"startTime"`
-	EndTime   string  `json:"endTime"`
+	Id        string    `json:"id"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author"`
+	Type      NotamType `json:"type"`
+	SessionId string    `json:"sessionId"`
+	Radius    int       `json:"radius"`
+	Message   string    `json:"message"`
+	Longitude float64   `json:"longitude"`
+	Latitude  float64   `json:"latitude"`
+	Icao      string    `json:"icao"`
+	Floor     int       `json:"floor"`
+	Ceiling   int       `json:"ceiling"`
+	StartTime Time      `json:"startTime"`
+	EndTime   Time      `json:"endTime"`
 }
 
 type Aircraft struct {
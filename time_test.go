@@ -0,0 +1,70 @@
+package golive
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeFormats(t *testing.T) {
+	expected := time.Date(2022, 10, 1, 18, 4, 5, 0, time.UTC)
+	for _, value := range []string{
+		`"2022-10-01 18:04:05Z"`,
+		`"2022-10-01T18:04:05Z"`,
+		`"2022-10-01T18:04:05"`,
+		`"2022-10-01 18:04:05"`,
+		`"2022-10-01T20:04:05+02:00"`,
+	} {
+		var decoded Time
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			t.Errorf("%s: %v", value, err)
+			continue
+		}
+		if !decoded.Time().Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", value, expected, decoded)
+		}
+	}
+
+	var fractional Time
+	if err := json.Unmarshal([]byte(`"2022-10-01 18:04:05.1234567Z"`), &fractional); err != nil {
+		t.Fatal(err)
+	}
+	if fractional.Time().Nanosecond() != 123456700 {
+		t.Errorf("unexpected fractional seconds %v", fractional)
+	}
+
+	for _, value := range []string{`null`, `""`} {
+		decoded := Time(expected)
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil || !decoded.IsZero() {
+			t.Errorf("%s: expected zero time, got %v, %v", value, decoded, err)
+		}
+	}
+
+	var invalid Time
+	if err := json.Unmarshal([]byte(`"yesterday"`), &invalid); err == nil {
+		t.Error("expected error for invalid time")
+	}
+}
+
+func TestTimeRoundTrip(t *testing.T) {
+	report := LoggedAtcSession{
+		Created: Time(time.Date(2022, 10, 1, 18, 4, 5, 500000000, time.UTC)),
+	}
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded LoggedAtcSession
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Created.Time().Equal(report.Created.Time()) || !decoded.Updated.IsZero() {
+		t.Errorf("round trip through %s gave %+v", b, decoded)
+	}
+
+	text, _ := report.Created.MarshalText()
+	var fromText Time
+	if err := fromText.UnmarshalText(text); err != nil || !fromText.Time().Equal(report.Created.Time()) {
+		t.Errorf("text round trip through %q gave %v, %v", text, fromText, err)
+	}
+}
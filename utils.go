@@ -1,6 +1,10 @@
 package golive
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -8,26 +12,38 @@ import (
 
 ////// ERRORS
 
+// ApiError is an error code reported by the Live API in the response envelope.
+// Compare against the Err* constants with errors.Is.
 type ApiError int
 
+const (
+	ErrUserNotFound      ApiError = 1
+	ErrMissingParameters ApiError = 2
+	ErrEndpoint          ApiError = 3
+	ErrUnauthorized      ApiError = 4
+	ErrServerNotFound    ApiError = 5
+	ErrFlightNotFound    ApiError = 6
+	ErrNoAtis            ApiError = 7
+)
+
 func (e ApiError) Error() string {
 	var description string
 	switch e {
 	case 0:
 		description = "OK"
-	case 1:
+	case ErrUserNotFound:
 		description = "User not found"
-	case 2:
+	case ErrMissingParameters:
 		description = "Missing request parameters"
-	case 3:
+	case ErrEndpoint:
 		description = "Endpoint error"
-	case 4:
+	case ErrUnauthorized:
 		description = "Not authorised (check API key)"
-	case 5:
+	case ErrServerNotFound:
 		description = "Server not found"
-	case 6:
+	case ErrFlightNotFound:
 		description = "Flight not found"
-	case 7:
+	case ErrNoAtis:
 		description = "No ATIS available"
 	default:
 		description = "Undocumented error code"
@@ -35,23 +51,153 @@ func (e ApiError) Error() string {
 	return "Live API error " + strconv.Itoa(int(e)) + ": " + description
 }
 
+// RequestError wraps an *HTTPError, ApiError or decoding error
+// with the endpoint that produced it.
+type RequestError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	return e.Method + " " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// Upper bound on how much of a response body is kept in an HTTPError
+const maxErrorBodySize = 1 << 10
+
+// HTTPError is returned when the Live API responds with a non-2xx status code.
+// Endpoint methods wrap it, use errors.As to retrieve it.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Body holds at most the first 1 KiB of the response body
+	Body []byte
+}
+
+func newHTTPError(response *http.Response) *HTTPError {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	return &HTTPError{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Header:     response.Header,
+		Body:       body,
+	}
+}
+
+func (e *HTTPError) Error() string {
+	status := e.Status
+	if status == "" {
+		status = strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+	}
+	message := "Live API HTTP error " + status
+	if body := strings.TrimSpace(string(e.Body)); body != "" {
+		message += ": " + body
+	}
+	return message
+}
+
+// Is reports 401 and 403 responses as ErrUnauthorized,
+// so errors.Is(err, ErrUnauthorized) holds whichever way the API rejects the key.
+func (e *HTTPError) Is(target error) bool {
+	if target == ErrUnauthorized {
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
 ////// TIME
 
-const (
-	layoutWithoutT = "2006-01-02 15:04:05Z"
-)
+// Layouts accepted by Time, after a space between date and time is replaced by a T
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
 
-type TimeWithoutT time.Time
+// Time is a timestamp as returned by the Live API, which uses several formats:
+// with or without a T between date and time, with or without fractional seconds and zone,
+// timestamps without a zone being UTC. null and empty strings decode to the zero Time.
+// It encodes as RFC 3339, or null if zero.
+type Time time.Time
 
-func (t *TimeWithoutT) UnmarshalJSON(b []byte) error {
-	time, err := time.Parse(layoutWithoutT, strings.Trim(string(b), `"`))
+// TimeWithoutT is the former name of Time.
+//
+// Deprecated: use Time.
+type TimeWithoutT = Time
+
+// ParseTime parses a timestamp in any of the formats used by the Live API.
+func ParseTime(value string) (Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Time{}, nil
+	}
+	normalized := value
+	if len(normalized) > 10 && normalized[10] == ' ' {
+		normalized = normalized[:10] + "T" + normalized[11:]
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, normalized); err == nil {
+			return Time(t), nil
+		}
+	}
+	return Time{}, fmt.Errorf("golive: cannot parse time %q", value)
+}
+
+// Time returns t as a time.Time.
+func (t Time) Time() time.Time {
+	return time.Time(t)
+}
+
+// IsZero reports whether t is the zero time, as decoded from null or an empty string.
+func (t Time) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+func (t Time) String() string {
+	return time.Time(t).String()
+}
+
+func (t *Time) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*t = Time{}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(b, &value); err != nil {
+		return fmt.Errorf("golive: cannot parse time %s", b)
+	}
+	parsed, err := ParseTime(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(time.Time(t).Format(time.RFC3339Nano))
+}
+
+func (t *Time) UnmarshalText(b []byte) error {
+	parsed, err := ParseTime(string(b))
 	if err != nil {
 		return err
 	}
-	*t = TimeWithoutT(time)
+	*t = parsed
 	return nil
 }
 
-func (t TimeWithoutT) MarshalJSON() ([]byte, error) {
-	return []byte(time.Time(t).Format(layoutWithoutT)), nil
+func (t Time) MarshalText() ([]byte, error) {
+	if t.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(time.Time(t).Format(time.RFC3339Nano)), nil
 }
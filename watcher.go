@@ -0,0 +1,258 @@
+package golive
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sqeezelemon/golive/geo"
+)
+
+// Event is emitted by watchers, use a type switch to tell the kinds apart.
+type Event interface {
+	event()
+}
+
+// FlightSpawned is emitted when a flight appears in a session.
+type FlightSpawned struct {
+	Flight Flight
+}
+
+// FlightDespawned is emitted when a flight leaves a session, with its last known state.
+type FlightDespawned struct {
+	Flight Flight
+}
+
+// FlightUpdated is emitted when a flight reports a new position.
+type FlightUpdated struct {
+	Previous Flight
+	Current  Flight
+	Delta    PositionDelta
+}
+
+// PositionDelta is the change between two reports of a flight.
+type PositionDelta struct {
+	// Distance travelled along the great circle in nautical miles
+	Distance  float64
+	Latitude  float64
+	Longitude float64
+	// Altitude in feet
+	Altitude float64
+	// Speed in knots
+	Speed float64
+	// Heading in degrees, between -180 and 180
+	Heading float64
+	Elapsed time.Duration
+}
+
+// CallsignChanged is emitted when a flight changes its callsign.
+type CallsignChanged struct {
+	Flight   Flight
+	Previous string
+	Current  string
+}
+
+// LiveryChanged is emitted when a flight changes its livery or aircraft.
+type LiveryChanged struct {
+	Flight             Flight
+	PreviousAircraftId string
+	PreviousLiveryId   string
+}
+
+// WatchError is emitted when a watcher fails to poll, it keeps trying with a growing delay.
+type WatchError struct {
+	Err error
+	// Failures is the number of consecutive failed polls.
+	Failures int
+}
+
+func (FlightSpawned) event()   {}
+func (FlightDespawned) event() {}
+func (FlightUpdated) event()   {}
+func (CallsignChanged) event() {}
+func (LiveryChanged) event()   {}
+func (WatchError) event()      {}
+
+// WatcherOptions controls how often a watcher polls.
+type WatcherOptions struct {
+	// Interval between polls, 15 seconds if zero.
+	Interval time.Duration
+	// MaxBackoff caps the delay between polls after failures, 5 minutes if zero.
+	// The delay doubles from Interval with every consecutive failure.
+	MaxBackoff time.Duration
+	// Buffer is the capacity of the event channel returned by Run.
+	Buffer int
+	// SkipInitial suppresses the spawn events for flights present at the first poll.
+	SkipInitial bool
+}
+
+// Internal function that fills in default options
+func (o *WatcherOptions) withDefaults() WatcherOptions {
+	var options WatcherOptions
+	if o != nil {
+		options = *o
+	}
+	if options.Interval <= 0 {
+		options.Interval = 15 * time.Second
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = 5 * time.Minute
+	}
+	return options
+}
+
+// Watcher polls the flights of a session and emits events as they change.
+type Watcher struct {
+	client    *Client
+	sessionId string
+	options   WatcherOptions
+
+	mutex       sync.Mutex
+	initialized bool
+	flights     []Flight
+	byId        map[string]Flight
+}
+
+// NewWatcher creates a watcher for the flights of a session.
+// A nil options uses the defaults.
+func NewWatcher(client *Client, sessionId string, options *WatcherOptions) *Watcher {
+	return &Watcher{
+		client:    client,
+		sessionId: sessionId,
+		options:   options.withDefaults(),
+		byId:      map[string]Flight{},
+	}
+}
+
+// Flights returns the flights seen at the last successful poll.
+func (w *Watcher) Flights() []Flight {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]Flight(nil), w.flights...)
+}
+
+// Poll fetches the flights once and returns the events since the previous poll.
+// Events are ordered by flight as returned by the API, despawns come last.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	flights, err := w.client.GetFlightsCtx(ctx, w.sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	quiet := !w.initialized && w.options.SkipInitial
+	w.initialized = true
+
+	var events []Event
+	current := make(map[string]Flight, len(flights))
+	for _, flight := range flights {
+		current[flight.Id] = flight
+		previous, ok := w.byId[flight.Id]
+		if !ok {
+			if !quiet {
+				events = append(events, FlightSpawned{flight})
+			}
+			continue
+		}
+		events = append(events, flightChanges(previous, flight)...)
+	}
+	for _, flight := range w.flights {
+		if _, ok := current[flight.Id]; !ok {
+			events = append(events, FlightDespawned{flight})
+		}
+	}
+
+	w.flights = flights
+	w.byId = current
+	return events, nil
+}
+
+// Run polls until ctx is done, sending events to the returned channel,
+// which is closed when Run stops.
+func (w *Watcher) Run(ctx context.Context) <-chan Event {
+	return runPoller(ctx, w.options, w.Poll)
+}
+
+// Internal function that lists the events between two states of a flight
+func flightChanges(previous Flight, current Flight) []Event {
+	var events []Event
+	if previous.Callsign != current.Callsign {
+		events = append(events, CallsignChanged{current, previous.Callsign, current.Callsign})
+	}
+	if previous.LiveryId != current.LiveryId || previous.AircraftId != current.AircraftId {
+		events = append(events, LiveryChanged{current, previous.AircraftId, previous.LiveryId})
+	}
+
+	delta := PositionDelta{
+		Distance:  geo.Distance(previous.Position(), current.Position()),
+		Latitude:  current.Latitude - previous.Latitude,
+		Longitude: current.Longitude - previous.Longitude,
+		Altitude:  current.Altitude - previous.Altitude,
+		Speed:     current.Speed - previous.Speed,
+		Heading:   headingDifference(previous.Heading, current.Heading),
+		Elapsed:   current.LastReport.Time().Sub(previous.LastReport.Time()),
+	}
+	if delta != (PositionDelta{}) {
+		events = append(events, FlightUpdated{previous, current, delta})
+	}
+	return events
+}
+
+// Internal function that returns the signed difference between two headings, between -180 and 180
+func headingDifference(from float64, to float64) float64 {
+	difference := to - from
+	for difference > 180 {
+		difference -= 360
+	}
+	for difference < -180 {
+		difference += 360
+	}
+	return difference
+}
+
+// Internal function that calls poll every interval until ctx is done,
+// backing off after failures, and sends the events to the returned channel
+func runPoller(ctx context.Context, options WatcherOptions, poll func(context.Context) ([]Event, error)) <-chan Event {
+	events := make(chan Event, options.Buffer)
+	go func() {
+		defer close(events)
+		failures := 0
+		for {
+			delay := options.Interval
+			polled, err := poll(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				failures++
+				polled = []Event{WatchError{err, failures}}
+				for i := 1; i < failures && delay < options.MaxBackoff; i++ {
+					delay *= 2
+				}
+				if delay > options.MaxBackoff {
+					delay = options.MaxBackoff
+				}
+			} else {
+				failures = 0
+			}
+
+			for _, event := range polled {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return events
+}
@@ -0,0 +1,115 @@
+package golive_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sqeezelemon/golive"
+	"github.com/sqeezelemon/golive/golivetest"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	watcher := golive.NewWatcher(server.Client(), golivetest.ExpertSessionId, nil)
+	ctx := context.Background()
+
+	events, err := watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 spawns, got %+v", events)
+	}
+	for _, event := range events {
+		if _, ok := event.(golive.FlightSpawned); !ok {
+			t.Errorf("expected FlightSpawned, got %T", event)
+		}
+	}
+
+	if events, _ := watcher.Poll(ctx); len(events) != 0 {
+		t.Errorf("expected no events without changes, got %+v", events)
+	}
+
+	server.Update(func(f *golivetest.Fixtures) {
+		flights := f.Flights[golivetest.ExpertSessionId]
+		delta := flights[0]
+		delta.Latitude += 0.05
+		delta.Altitude += 1000
+		delta.Heading = 10
+		delta.LastReport = golive.Time(delta.LastReport.Time().Add(15 * time.Second))
+		speedbird := flights[1]
+		speedbird.Callsign = "Speedbird 9"
+		speedbird.LiveryId = "other-livery"
+		spawned := golive.Flight{Id: "new-flight", Callsign: "Cessna 1"}
+		// Southwest despawns
+		f.Flights[golivetest.ExpertSessionId] = []golive.Flight{delta, speedbird, spawned}
+	})
+
+	events, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %+v", events)
+	}
+	updated, ok := events[0].(golive.FlightUpdated)
+	if !ok || updated.Delta.Altitude != 1000 || updated.Delta.Heading != 80 || updated.Delta.Elapsed != 15*time.Second {
+		t.Errorf("unexpected update %+v", events[0])
+	}
+	if changed, ok := events[1].(golive.CallsignChanged); !ok || changed.Previous != "Speedbird 283" || changed.Current != "Speedbird 9" {
+		t.Errorf("unexpected callsign change %+v", events[1])
+	}
+	if changed, ok := events[2].(golive.LiveryChanged); !ok || changed.PreviousLiveryId != golivetest.BritId {
+		t.Errorf("unexpected livery change %+v", events[2])
+	}
+	if spawned, ok := events[3].(golive.FlightSpawned); !ok || spawned.Flight.Id != "new-flight" {
+		t.Errorf("unexpected spawn %+v", events[3])
+	}
+	if despawned, ok := events[4].(golive.FlightDespawned); !ok || despawned.Flight.Id != golivetest.SouthwestFlightId {
+		t.Errorf("unexpected despawn %+v", events[4])
+	}
+	if flights := watcher.Flights(); len(flights) != 3 || flights[2].Id != "new-flight" {
+		t.Errorf("unexpected watcher state %+v", flights)
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	server := golivetest.NewServer(nil)
+	defer server.Close()
+	server.Inject(golivetest.Fault{Status: http.StatusBadGateway, Times: 2})
+
+	watcher := golive.NewWatcher(server.Client(), golivetest.ExpertSessionId, &golive.WatcherOptions{
+		Interval:    time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		SkipInitial: true,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := watcher.Run(ctx)
+
+	for i := 1; i <= 2; i++ {
+		event := <-events
+		if watchErr, ok := event.(golive.WatchError); !ok || watchErr.Failures != i {
+			t.Fatalf("expected WatchError %d, got %+v", i, event)
+		}
+	}
+
+	// Wait for the first successful poll, then make a change
+	for len(watcher.Flights()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	server.Update(func(f *golivetest.Fixtures) {
+		f.Flights[golivetest.ExpertSessionId] = f.Flights[golivetest.ExpertSessionId][1:]
+	})
+	event := <-events
+	if despawned, ok := event.(golive.FlightDespawned); !ok || despawned.Flight.Id != golivetest.DeltaFlightId {
+		t.Errorf("expected Delta to despawn, got %+v", event)
+	}
+
+	cancel()
+	for range events {
+	}
+}